@@ -0,0 +1,47 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+var alignWidth int32
+
+// Atomic change of the column width of the "file:line:" prefix of FileLine,
+// TimeFileLine, FileLineFunc, AbsFile, and ShortFile styles. Shorter
+// prefixes are padded with spaces so messages align; longer ones keep their
+// tail after an ellipsis, e.g. with width 16,
+//
+//	dbg.go:22:       up
+//	…/module.go:122: down
+//
+// The default, 0, doesn't align.
+func SetAlignPrefix(width int) {
+	if width < 0 {
+		width = 0
+	}
+	atomic.StoreInt32(&alignWidth, int32(width))
+}
+
+// Return the "file:line:" prefix padded or cut to the align width.
+func align(s string) string {
+	width := int(atomic.LoadInt32(&alignWidth))
+	if width == 0 {
+		return s
+	}
+	n := utf8.RuneCountInString(s)
+	if n <= width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	i := 0
+	for drop := n - width + 1; drop > 0; drop-- {
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+	return "…" + s[i:]
+}
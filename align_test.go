@@ -0,0 +1,51 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+func TestAlignPrefix(t *testing.T) {
+	defer Writer(CurrentWriter())
+	defer SetAlignPrefix(0)
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	SetAlignPrefix(20)
+	_, _, line, _ := runtime.Caller(0)
+	FileLine.Log("padded")
+	SetAlignPrefix(10)
+	FileLine.Log("cut")
+	Func.Log("func")
+	if got, want := buf.String(), fmt.Sprint(
+		"align_test.go:", line+1, ":    padded\n",
+		"…st.go:", line+3, ": cut\n",
+		"github.com/platinasystems/dbg.TestAlignPrefix() func\n",
+	); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestAlign(t *testing.T) {
+	defer SetAlignPrefix(0)
+	for _, x := range []struct {
+		width   int
+		in, out string
+	}{
+		{0, "a.go:1:", "a.go:1:"},
+		{7, "a.go:1:", "a.go:1:"},
+		{9, "a.go:1:", "a.go:1:  "},
+		{6, "a.go:1:", "…go:1:"},
+		{6, "é.go:1:", "…go:1:"},
+	} {
+		SetAlignPrefix(x.width)
+		if got := align(x.in); got != x.out {
+			t.Errorf("%d %q got %q, want %q", x.width, x.in, got, x.out)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+// Policy of AsyncWriter.Write with a full buffer: BlockWhenFull or
+// DropOldest.
+type DropPolicy int32
+
+const (
+	BlockWhenFull DropPolicy = iota
+	DropOldest
+)
+
+// AsyncWriter queues writes to a background goroutine.
+type AsyncWriter struct {
+	w       io.Writer
+	mu      sync.Mutex
+	cond    sync.Cond
+	queue   [][]byte
+	max     int
+	busy    bool
+	closed  bool
+	err     error
+	policy  int32
+	dropped uint64
+	done    chan struct{}
+}
+
+// Return an AsyncWriter that queues as many as bufSize writes for a
+// background goroutine to forward to w. Use it with dbg.Writer to move the
+// output of each line off the logging goroutine. Close the AsyncWriter to
+// flush the queue and stop the background goroutine.
+func NewAsyncWriter(w io.Writer, bufSize int) *AsyncWriter {
+	if bufSize < 1 {
+		bufSize = 1
+	}
+	aw := &AsyncWriter{
+		w:    w,
+		max:  bufSize,
+		done: make(chan struct{}),
+	}
+	aw.cond.L = &aw.mu
+	go aw.loop()
+	return aw
+}
+
+// Atomic change of the full buffer policy; the default is BlockWhenFull.
+func (aw *AsyncWriter) SetDropPolicy(policy DropPolicy) {
+	atomic.StoreInt32(&aw.policy, int32(policy))
+}
+
+// Return the number of writes dropped with the DropOldest policy.
+func (aw *AsyncWriter) Dropped() uint64 {
+	return atomic.LoadUint64(&aw.dropped)
+}
+
+// Queue a copy of b; this fails with os.ErrClosed after Close.
+func (aw *AsyncWriter) Write(b []byte) (int, error) {
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+	for !aw.closed && len(aw.queue) >= aw.max {
+		if DropPolicy(atomic.LoadInt32(&aw.policy)) == DropOldest {
+			aw.queue[0] = nil
+			aw.queue = aw.queue[1:]
+			atomic.AddUint64(&aw.dropped, 1)
+		} else {
+			aw.cond.Wait()
+		}
+	}
+	if aw.closed {
+		return 0, os.ErrClosed
+	}
+	aw.queue = append(aw.queue, append([]byte(nil), b...))
+	aw.cond.Broadcast()
+	return len(b), nil
+}
+
+// Wait for the queue to drain then return the first error, if any, from the
+// wrapped writer.
+func (aw *AsyncWriter) Flush() error {
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+	for len(aw.queue) > 0 || aw.busy {
+		aw.cond.Wait()
+	}
+	return aw.err
+}
+
+// Flush then stop the background goroutine. This doesn't close the wrapped
+// writer.
+func (aw *AsyncWriter) Close() error {
+	aw.mu.Lock()
+	aw.closed = true
+	aw.cond.Broadcast()
+	aw.mu.Unlock()
+	<-aw.done
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+	return aw.err
+}
+
+func (aw *AsyncWriter) loop() {
+	defer close(aw.done)
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+	for {
+		for len(aw.queue) == 0 && !aw.closed {
+			aw.cond.Wait()
+		}
+		if len(aw.queue) == 0 {
+			return
+		}
+		b := aw.queue[0]
+		aw.queue[0] = nil
+		aw.queue = aw.queue[1:]
+		aw.busy = true
+		aw.mu.Unlock()
+		_, err := aw.w.Write(b)
+		aw.mu.Lock()
+		aw.busy = false
+		if err != nil && aw.err == nil {
+			aw.err = err
+		}
+		aw.cond.Broadcast()
+	}
+}
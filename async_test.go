@@ -0,0 +1,88 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"testing"
+)
+
+type gateWriter struct {
+	bytes.Buffer
+	started, release chan struct{}
+}
+
+func (w *gateWriter) Write(b []byte) (int, error) {
+	if w.started != nil {
+		close(w.started)
+		w.started = nil
+		<-w.release
+	}
+	return w.Buffer.Write(b)
+}
+
+func TestAsyncWriter(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	aw := NewAsyncWriter(buf, 4)
+	Writer(aw)
+	want := new(bytes.Buffer)
+	for i := 0; i < 100; i++ {
+		Plain.Log("line", i)
+		fmt.Fprintln(want, "line", i)
+		if i == 49 {
+			if err := aw.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			if buf.Len() != want.Len() {
+				t.Fatal("incomplete flush")
+			}
+		}
+	}
+	if err := aw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != want.String() {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+	if _, err := aw.Write([]byte("closed\n")); err != os.ErrClosed {
+		t.Error("Write after Close:", err)
+	}
+}
+
+func TestAsyncWriterDropOldest(t *testing.T) {
+	gw := &gateWriter{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	started := gw.started
+	aw := NewAsyncWriter(gw, 2)
+	aw.SetDropPolicy(DropOldest)
+	aw.Write([]byte("a\n"))
+	<-started
+	for _, s := range []string{"b\n", "c\n", "d\n", "e\n"} {
+		aw.Write([]byte(s))
+	}
+	close(gw.release)
+	if err := aw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := gw.String(), "a\nd\ne\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if n := aw.Dropped(); n != 2 {
+		t.Error("dropped", n)
+	}
+}
+
+func TestAsyncWriterError(t *testing.T) {
+	aw := NewAsyncWriter(errWriter{os.ErrPermission}, 1)
+	aw.Write([]byte("x\n"))
+	if err := aw.Close(); err != os.ErrPermission {
+		t.Fatal("Close:", err)
+	}
+}
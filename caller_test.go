@@ -0,0 +1,111 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// The function of the stubbed caller frame.
+func stubbedCaller() {}
+
+func stubCaller(file string, line int) func() {
+	pc := reflect.ValueOf(stubbedCaller).Pointer() + 1
+	fn := callerFn
+	callerFn = func(int) (uintptr, string, int, bool) {
+		return pc, file, line, true
+	}
+	return func() { callerFn = fn }
+}
+
+func TestHermeticStyles(t *testing.T) {
+	defer Writer(CurrentWriter())
+	defer SetTrimPrefixes()
+	defer stubCaller("/src/example.com/cmd/main.go", 7)()
+	defer fakeNow(time.Date(2018, 10, 23, 10, 4, 5, 6000, time.UTC))()
+	SetTrimPrefixes("/src/")
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	for style := NoOp; style < nStyles; style++ {
+		style.Log("text")
+	}
+	const fn = "github.com/platinasystems/dbg.stubbedCaller"
+	want := "text\n" +
+		"example.com/cmd/main.go:7: text\n" +
+		fn + "() text\n" +
+		fn + "() example.com/cmd/main.go:7: text\n" +
+		"2018-10-23T10:04:05.000006Z text\n" +
+		"2018-10-23T10:04:05.000006Z example.com/cmd/main.go:7: text\n" +
+		`{"file":"example.com/cmd/main.go","line":7,"func":"` + fn +
+		`","msg":"text"}` + "\n" +
+		"dbg.stubbedCaller() text\n" +
+		"example.com/cmd/main.go:7: text\n" +
+		"github.com/platinasystems/dbg: text\n" +
+		"main.go:7: text\n" +
+		"file=example.com/cmd/main.go line=7 func=" + fn + " msg=text\n"
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestPlainSkipsCaller(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	fn := callerFn
+	defer func() { callerFn = fn }()
+	calls := 0
+	callerFn = func(skip int) (uintptr, string, int, bool) {
+		calls++
+		return fn(skip + 1)
+	}
+	Plain.Log("log")
+	Plain.Logf("%s", "logf")
+	log.New(Plain.AsWriter(), "", 0).Print("std")
+	if got, want := buf.String(), "log\nlogf\nstd\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if calls != 0 {
+		t.Errorf("Plain resolved the caller %d times", calls)
+	}
+	FileLine.Log("framed")
+	if calls != 1 {
+		t.Errorf("FileLine resolved the caller %d times", calls)
+	}
+}
+
+func BenchmarkPlain(b *testing.B) {
+	defer Writer(CurrentWriter())
+	Writer(io.Discard)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Plain.Log("hot")
+	}
+}
+
+func BenchmarkFileLine(b *testing.B) {
+	defer Writer(CurrentWriter())
+	Writer(io.Discard)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		FileLine.Log("hot")
+	}
+}
+
+func BenchmarkPlainAsWriter(b *testing.B) {
+	defer Writer(CurrentWriter())
+	Writer(io.Discard)
+	w := Plain.AsWriter()
+	line := []byte("hot\n")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		w.Write(line)
+	}
+}
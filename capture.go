@@ -0,0 +1,22 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import "bytes"
+
+// Change the default writer to a new buffer and return it with a func that
+// restores the previous default, e.g.
+//
+//	buf, restore := dbg.Capture()
+//	defer restore()
+//
+// Captures may nest if each is restored in reverse order. This is intended
+// for tests that log from a single goroutine.
+func Capture() (*bytes.Buffer, func()) {
+	prev, _ := writer.Load().(writerBox)
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	return buf, func() { writer.Store(prev) }
+}
@@ -0,0 +1,33 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCapture(t *testing.T) {
+	defer Writer(CurrentWriter())
+	prev := new(bytes.Buffer)
+	Writer(prev)
+	outer, restoreOuter := Capture()
+	Plain.Log("outer")
+	inner, restoreInner := Capture()
+	Plain.Log("inner")
+	restoreInner()
+	Plain.Log("outer again")
+	restoreOuter()
+	Plain.Log("prev")
+	if got, want := outer.String(), "outer\nouter again\n"; got != want {
+		t.Errorf("outer got %q, want %q", got, want)
+	}
+	if got, want := inner.String(), "inner\n"; got != want {
+		t.Errorf("inner got %q, want %q", got, want)
+	}
+	if got, want := prev.String(), "prev\n"; got != want {
+		t.Errorf("prev got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+var closers struct {
+	sync.Mutex
+	list []io.Closer
+}
+
+// Append a closer, e.g. a gzip, rotating, or async writer, for CloseAll.
+func RegisterCloser(c io.Closer) {
+	closers.Lock()
+	defer closers.Unlock()
+	closers.list = append(closers.list, c)
+}
+
+// Close and forget the registered closers in reverse order of registration,
+// then return their joined errors, if any. Use this to flush writers before
+// exit,
+//
+//	func main() {
+//		defer dbg.CloseAll()
+//		...
+//	}
+func CloseAll() error {
+	closers.Lock()
+	list := closers.list
+	closers.list = nil
+	closers.Unlock()
+	var errs []error
+	for i := len(list) - 1; i >= 0; i-- {
+		if err := list[i].Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,43 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+)
+
+type testCloser struct {
+	name   string
+	err    error
+	closed *[]string
+}
+
+func (c testCloser) Close() error {
+	*c.closed = append(*c.closed, c.name)
+	return c.err
+}
+
+func TestCloseAll(t *testing.T) {
+	var closed []string
+	RegisterCloser(testCloser{"first", nil, &closed})
+	RegisterCloser(testCloser{"second", os.ErrInvalid, &closed})
+	err := CloseAll()
+	if !errors.Is(err, os.ErrInvalid) {
+		t.Errorf("got %v, want %v", err, os.ErrInvalid)
+	}
+	want := []string{"second", "first"}
+	if !reflect.DeepEqual(closed, want) {
+		t.Errorf("closed %q, want %q", closed, want)
+	}
+	if err = CloseAll(); err != nil {
+		t.Errorf("second CloseAll got %v", err)
+	}
+	if len(closed) != 2 {
+		t.Errorf("closed again %q", closed)
+	}
+}
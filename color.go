@@ -0,0 +1,135 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	colorAuto int32 = iota
+	colorOff
+	colorOn
+)
+
+const (
+	ansiPrefix = "\x1b[36m"
+	ansiReset  = "\x1b[0m"
+)
+
+var (
+	color       int32
+	colorLine   int32
+	levelColors [nLevels]atomic.Value
+	ttys        struct {
+		stdout, stderr struct {
+			once sync.Once
+			val  bool
+		}
+	}
+)
+
+// Atomic override of prefix color. By default, the prefix is colored only
+// when writing to an os.Stdout or os.Stderr terminal. SetColor(true) colors
+// the prefix regardless of the writer and SetColor(false) never colors.
+// In either case, a non-empty NO_COLOR environment variable disables color.
+func SetColor(enable bool) {
+	if enable {
+		atomic.StoreInt32(&color, colorOn)
+	} else {
+		atomic.StoreInt32(&color, colorOff)
+	}
+}
+
+func colorize(w io.Writer) bool {
+	if len(os.Getenv("NO_COLOR")) > 0 {
+		return false
+	}
+	switch atomic.LoadInt32(&color) {
+	case colorOn:
+		return true
+	case colorOff:
+		return false
+	}
+	return isatty(w)
+}
+
+// Atomic change of the ANSI color code of the level's prefix, e.g.
+// "\x1b[33m" for yellow. The defaults are gray Debug, yellow Warn, and red
+// Error, whereas an empty code, the Info default, is cyan.
+func SetLevelColor(level Level, code string) {
+	if level >= 0 && level < nLevels {
+		levelColors[level].Store(code)
+	}
+}
+
+// Atomic change of whether the level color wraps the whole line, instead of
+// just the prefix. Lines with an empty level color still color only the
+// prefix.
+func SetColorLine(enable bool) {
+	var v int32
+	if enable {
+		v = 1
+	}
+	atomic.StoreInt32(&colorLine, v)
+}
+
+// Return the level's color code, or, if empty, that of the prefix.
+func levelColor(level Level) string {
+	if level < 0 || level >= nLevels {
+		return ansiPrefix
+	}
+	code, ok := levelColors[level].Load().(string)
+	if !ok {
+		code = []string{
+			"\x1b[90m",
+			"",
+			"\x1b[33m",
+			"\x1b[31m",
+		}[level]
+	}
+	if len(code) == 0 {
+		return ansiPrefix
+	}
+	return code
+}
+
+// Return the prefixed message with the level's color codes.
+func colored(level Level, p, msg string) string {
+	code := levelColor(level)
+	if atomic.LoadInt32(&colorLine) != 0 && code != ansiPrefix {
+		return code + p + msg + ansiReset
+	}
+	if len(p) == 0 {
+		return msg
+	}
+	s := strings.TrimRight(p, " ")
+	return code + s + ansiReset + p[len(s):] + msg
+}
+
+func isatty(w io.Writer) bool {
+	switch w {
+	case os.Stdout:
+		ttys.stdout.once.Do(func() {
+			ttys.stdout.val = ischardev(os.Stdout)
+		})
+		return ttys.stdout.val
+	case os.Stderr:
+		ttys.stderr.once.Do(func() {
+			ttys.stderr.val = ischardev(os.Stderr)
+		})
+		return ttys.stderr.val
+	}
+	return false
+}
+
+func ischardev(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,102 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	defer atomic.StoreInt32(&color, atomic.LoadInt32(&color))
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	FileLine.Log("auto")
+	if strings.Contains(buf.String(), "\x1b") {
+		t.Fatalf("auto: escape sequence in %q", buf)
+	}
+
+	buf.Reset()
+	SetColor(true)
+	FileLine.Log("forced")
+	got := buf.String()
+	if !strings.HasPrefix(got, ansiPrefix+"color_test.go:") ||
+		!strings.HasSuffix(got, ":"+ansiReset+" forced\n") {
+		t.Fatalf("forced: %q", got)
+	}
+
+	buf.Reset()
+	Plain.Log("plain")
+	if got = buf.String(); got != "plain\n" {
+		t.Fatalf("plain: %q", got)
+	}
+
+	buf.Reset()
+	t.Setenv("NO_COLOR", "1")
+	FileLine.Log("disabled")
+	if strings.Contains(buf.String(), "\x1b") {
+		t.Fatalf("NO_COLOR: escape sequence in %q", buf)
+	}
+
+	buf.Reset()
+	t.Setenv("NO_COLOR", "")
+	SetColor(false)
+	FileLine.Log("off")
+	if strings.Contains(buf.String(), "\x1b") {
+		t.Fatalf("off: escape sequence in %q", buf)
+	}
+}
+
+func TestLevelColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	defer atomic.StoreInt32(&color, atomic.LoadInt32(&color))
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	SetColor(true)
+	const p = "github.com/platinasystems/dbg"
+	for _, tc := range []struct {
+		level Level
+		logf  func(string, ...interface{}) error
+		code  string
+	}{
+		{Debug, Pkg.Debugf, "\x1b[90m"},
+		{Info, Pkg.Infof, ansiPrefix},
+		{Warn, Pkg.Warnf, "\x1b[33m"},
+		{Error, Pkg.Errorf, "\x1b[31m"},
+	} {
+		buf.Reset()
+		tc.logf("%s", "msg")
+		if got, want := buf.String(),
+			tc.code+p+":"+ansiReset+" msg\n"; got != want {
+			t.Errorf("%v got %q, want %q", tc.level, got, want)
+		}
+	}
+
+	SetColorLine(true)
+	defer SetColorLine(false)
+	buf.Reset()
+	Pkg.Warnf("%s", "line")
+	Plain.Errorf("%s", "plain")
+	Pkg.Infof("%s", "info")
+	want := "\x1b[33m" + p + ": line" + ansiReset + "\n" +
+		"\x1b[31mplain" + ansiReset + "\n" +
+		ansiPrefix + p + ":" + ansiReset + " info\n"
+	if got := buf.String(); got != want {
+		t.Errorf("line got %q, want %q", got, want)
+	}
+
+	SetLevelColor(Warn, "\x1b[35m")
+	defer SetLevelColor(Warn, "\x1b[33m")
+	buf.Reset()
+	Plain.Warnf("%s", "magenta")
+	if got, want := buf.String(),
+		"\x1b[35mmagenta"+ansiReset+"\n"; got != want {
+		t.Errorf("SetLevelColor got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// A ContextExtractor returns key/value pairs from a context.
+type ContextExtractor func(context.Context) []interface{}
+
+type writerKey struct{}
+
+var extractors struct {
+	sync.Mutex
+	list atomic.Value
+}
+
+// Append an extractor of the key/value fields that LogCtx and LogfCtx
+// append to each message.
+func RegisterContextExtractor(f ContextExtractor) {
+	extractors.Lock()
+	defer extractors.Unlock()
+	list, _ := extractors.list.Load().([]ContextExtractor)
+	extractors.list.Store(append(list[:len(list):len(list)], f))
+}
+
+// Return a copy of ctx with a writer that LogCtx and LogfCtx prefer to that
+// of the style, e.g. to capture the debug output of each request. A nil w
+// reverts to the style's writer.
+func WithWriter(ctx context.Context, w io.Writer) context.Context {
+	return context.WithValue(ctx, writerKey{}, box(w))
+}
+
+// Like Log but with the key/values from the registered context extractors.
+func (style Style) LogCtx(ctx context.Context, args ...interface{}) error {
+	if style == NoOp {
+		return errarg(args)
+	}
+	l := Logger{style: style, w: ctxwriter(ctx), fields: ctxfields(ctx)}
+	return l.output(Info, 1, "", args)
+}
+
+// Like Logf but with the key/values from the registered context extractors.
+func (style Style) LogfCtx(ctx context.Context, format string,
+	args ...interface{}) error {
+	if style == NoOp {
+		return errarg(args)
+	}
+	l := Logger{style: style, w: ctxwriter(ctx), fields: ctxfields(ctx)}
+	return l.output(Info, 1, format, args)
+}
+
+func ctxfields(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	list, _ := extractors.list.Load().([]ContextExtractor)
+	var keyvals []interface{}
+	for _, f := range list {
+		keyvals = append(keyvals, f(ctx)...)
+	}
+	return logfmt(keyvals)
+}
+
+// Return the writer of WithWriter, or nil.
+func ctxwriter(ctx context.Context) io.Writer {
+	if ctx == nil {
+		return nil
+	}
+	b, _ := ctx.Value(writerKey{}).(writerBox)
+	return b.Writer
+}
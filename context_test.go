@@ -0,0 +1,74 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+type traceKey struct{}
+
+func TestLogCtx(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	defer func(list interface{}) {
+		if list != nil {
+			extractors.list.Store(list)
+		}
+	}(extractors.list.Load())
+	extractors.list.Store([]ContextExtractor(nil))
+
+	ctx := context.WithValue(context.Background(), traceKey{}, "abc")
+	Plain.LogCtx(ctx, "unregistered")
+	RegisterContextExtractor(func(ctx context.Context) []interface{} {
+		if id, ok := ctx.Value(traceKey{}).(string); ok {
+			return []interface{}{"trace", id}
+		}
+		return nil
+	})
+	Plain.LogCtx(ctx, "registered")
+	Plain.LogfCtx(ctx, "%s", "formatted")
+	Plain.LogCtx(context.Background(), "without")
+	Plain.LogCtx(nil, "nil")
+	want := `unregistered
+registered trace=abc
+formatted trace=abc
+without
+nil
+`
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWithWriter(t *testing.T) {
+	defer Writer(CurrentWriter())
+	global, a, b := new(bytes.Buffer), new(bytes.Buffer), new(bytes.Buffer)
+	Writer(global)
+	ctxA := WithWriter(context.Background(), a)
+	ctxB := WithWriter(context.Background(), b)
+	Plain.LogCtx(ctxA, "a1")
+	Plain.LogfCtx(ctxB, "%s", "b1")
+	Plain.LogCtx(context.Background(), "global")
+	Plain.LogfCtx(ctxA, "%s", "a2")
+	Plain.LogCtx(ctxB, "b2")
+	Plain.LogCtx(WithWriter(ctxA, nil), "cleared")
+	for _, x := range []struct {
+		name string
+		buf  *bytes.Buffer
+		want string
+	}{
+		{"a", a, "a1\na2\n"},
+		{"b", b, "b1\nb2\n"},
+		{"global", global, "global\ncleared\n"},
+	} {
+		if got := x.buf.String(); got != x.want {
+			t.Errorf("%s got %q, want %q", x.name, got, x.want)
+		}
+	}
+}
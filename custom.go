@@ -0,0 +1,80 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// A PrefixFunc returns the prefix of a line logged by the caller at the
+// given program counter, file, and line.
+type PrefixFunc func(pc uintptr, file string, line int) string
+
+type customStyle struct {
+	name   string
+	prefix atomic.Value
+}
+
+var customStyles struct {
+	sync.Mutex
+	list atomic.Value
+}
+
+// Return a new style of the given name, with an empty prefix until
+// SetPrefixFunc, e.g.
+//
+//	var Build = dbg.RegisterStyle("Build")
+//
+//	func init() {
+//		dbg.SetPrefixFunc(Build, func(pc uintptr, file string,
+//			line int) string {
+//			return "[" + sha + "] "
+//		})
+//	}
+//
+// ParseStyle also accepts the name.
+func RegisterStyle(name string) Style {
+	customStyles.Lock()
+	defer customStyles.Unlock()
+	list, _ := customStyles.list.Load().([]*customStyle)
+	customStyles.list.Store(append(list[:len(list):len(list)],
+		&customStyle{name: name}))
+	return nStyles + Style(len(list))
+}
+
+// Atomic change of a registered style's prefix func; this does nothing for
+// the predefined styles.
+func SetPrefixFunc(style Style, f PrefixFunc) {
+	if cs := style.custom(); cs != nil {
+		cs.prefix.Store(f)
+	}
+}
+
+// Return the registered style, or nil if predefined or unknown.
+func (style Style) custom() *customStyle {
+	list, _ := customStyles.list.Load().([]*customStyle)
+	if i := int(style - nStyles); style >= nStyles && i < len(list) {
+		return list[i]
+	}
+	return nil
+}
+
+// Return the prefix of the registered style.
+func (cs *customStyle) sprefix(pc uintptr, file string, line int) string {
+	if f, _ := cs.prefix.Load().(PrefixFunc); f != nil {
+		return f(pc, file, line)
+	}
+	return ""
+}
+
+func customStyleNames() []string {
+	list, _ := customStyles.list.Load().([]*customStyle)
+	names := make([]string, len(list))
+	for i, cs := range list {
+		names[i] = cs.name
+	}
+	return names
+}
@@ -0,0 +1,44 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestRegisterStyle(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	list, _ := customStyles.list.Load().([]*customStyle)
+	defer customStyles.list.Store(list)
+	customStyles.list.Store([]*customStyle(nil))
+	testBuildStyle := RegisterStyle("TestBuild")
+	if testBuildStyle != nStyles {
+		t.Fatalf("got %d, want nStyles", testBuildStyle)
+	}
+	testBuildStyle.Log("unset")
+	SetPrefixFunc(testBuildStyle, func(pc uintptr, file string,
+		line int) string {
+		return fmt.Sprint("[abc123 ", filepath.Base(file), ":", line, "] ")
+	})
+	testBuildStyle.Log("custom")
+	_, _, line, _ := runtime.Caller(0)
+	want := fmt.Sprint("unset\n[abc123 custom_test.go:", line-1, "] custom\n")
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+	if got := testBuildStyle.String(); got != "TestBuild" {
+		t.Errorf("String() = %q", got)
+	}
+	if style, err := ParseStyle("testbuild"); err != nil ||
+		style != testBuildStyle {
+		t.Errorf("ParseStyle got %v, %v", style, err)
+	}
+}
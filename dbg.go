@@ -10,16 +10,32 @@ Usage:
 	dbg.Style.Log(args...)
 	dbg.Style.Logf(format, args...)
 
-Where Style may be: NoOp, Plain, FileLine, or Func.
+Where Style may be NoOp, Plain, or one of the prefixed styles listed with
+the Style type, such as FileLine or Func; or JSON for one object per line
+with file, line, func, msg, and, if args[0] is an error, error fields; or
+Logfmt for the same fields as key=value pairs.
 
-Nothing is printed with NoOp style, no args, or a nil args[0].
+The package level Log and Logf print with the default logger, of the style
+named by the DBG_STYLE environment variable, else FileLine, unless changed by
+SetDefaultStyle, SetDefaultWriter, or SetDefaultPrefix.
+
+Nothing is printed with NoOp style, no args, a nil args[0], or while
+SetEnabled(false).
+
+Log and Logf are Info level. Use Debugf, Infof, Warnf, and Errorf with
+SetMinLevel to drop less severe messages before these are formatted.
+Errorf also returns its message as a new error.
 
 If args[0] is an error, both Log and Logf return that error; otherwise, these
 return nil. Use this to log a returned error,
 
 	return dbg.Style.Log(err)
 
-Use style variables to selectively enable output,
+SetErrorArgIndex selects another arg as the error; whereas, LogErr always
+returns its explicit error.
+
+Use style variables, or a Logger for an independent writer and prefix, to
+selectively enable output,
 
 	// PACKAGE.go
 	var Err = dbg.NoOp
@@ -45,139 +61,699 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
+	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 )
 
-// Styles: NoOp, Plain, FilLine, or Func.
+// Styles: NoOp, Plain, FileLine, Func, FileLineFunc, Time, TimeFileLine,
+// JSON, ShortFunc, AbsFile, Pkg, ShortFile, or Logfmt.
 type Style int
 
 const (
-	NoOp     Style = iota
-	Plain          // TEXT
-	FileLine       // github.com/platinasystems/dbg_test.go:22: TEXT
-	Func           // github.com/platinasystems/dbg.Test() TEXT
+	NoOp         Style = iota
+	Plain              // TEXT
+	FileLine           // github.com/platinasystems/dbg_test.go:22: TEXT
+	Func               // github.com/platinasystems/dbg.Test() TEXT
+	FileLineFunc       // github.com/platinasystems/dbg.Test() dbg_test.go:22: TEXT
+	Time               // 2018-10-23T10:04:05.000000-07:00 TEXT
+	TimeFileLine       // 2018-10-23T10:04:05.000000-07:00 dbg_test.go:22: TEXT
+	JSON               // {"file":"dbg_test.go","line":22,"func":...,"msg":TEXT}
+	ShortFunc          // dbg.Test() TEXT
+	AbsFile            // /home/user/dbg/dbg_test.go:22: TEXT
+	Pkg                // github.com/platinasystems/dbg: TEXT
+	ShortFile          // dbg_test.go:22: TEXT (no dir: fast but ambiguous)
+	Logfmt             // file=dbg_test.go line=22 func=... msg=TEXT
 	nStyles
 )
 
+// Boxed so that atomic.Value always stores the same concrete type.
+type writerBox struct{ io.Writer }
+
+// The sentinel stored for an unset writer.
+var unset writerBox
+
+// Box w, or, if w is nil or a typed nil pointer, return unset.
+func box(w io.Writer) writerBox {
+	if w == nil {
+		return unset
+	}
+	if v := reflect.ValueOf(w); v.Kind() == reflect.Ptr && v.IsNil() {
+		return unset
+	}
+	return writerBox{w}
+}
+
+// Return the writer boxed in v, or nil if v is unset.
+func unbox(v *atomic.Value) io.Writer {
+	b, _ := v.Load().(writerBox)
+	return b.Writer
+}
+
 var (
-	writer atomic.Value
-	cached struct {
-		gopath, gopathsrc, wd struct {
+	// Tests may replace this to resolve unusual relative paths.
+	relpath = filepath.Rel
+	// Tests may replace this for deterministic frames; like runtime.Caller,
+	// but the pc is that of runtime.Callers.
+	callerFn = callers1
+
+	writer       atomic.Value
+	styleWriters [nStyles]atomic.Value
+	errorWriter  atomic.Value
+	errorsToErr  int32
+	nocache      int32
+	pathBase     atomic.Value
+	trimPrefixes atomic.Value
+	frames       sync.Map
+	realpaths    sync.Map
+	cached       struct {
+		gopath, gopathsrcs, gorootsrc struct {
 			once sync.Once
 			val  interface{}
 		}
+		wd atomic.Value
 	}
 )
 
-// Atomic change of the os.Stdout default.
+func init() {
+	writer.Store(unset)
+	errorWriter.Store(unset)
+	for i := range styleWriters {
+		styleWriters[i].Store(unset)
+	}
+}
+
+// Atomic change of the os.Stdout default; a nil w, including a typed nil
+// pointer, reverts to os.Stdout.
 func Writer(w io.Writer) {
-	writer.Store(w)
+	writer.Store(box(w))
+}
+
+// Same as Writer(os.Stderr).
+func Stderr() {
+	Writer(os.Stderr)
+}
+
+// Same as Writer(os.Stdout).
+func Stdout() {
+	Writer(os.Stdout)
+}
+
+// Clear the Writer default, reverting to os.Stdout.
+func ResetWriter() {
+	Writer(nil)
+}
+
+// Return the Writer default, os.Stdout if unset. Use this to save and
+// restore the default around a test,
+//
+//	defer dbg.Writer(dbg.CurrentWriter())
+//	dbg.Writer(buf)
+func CurrentWriter() io.Writer {
+	if w := unbox(&writer); w != nil {
+		return w
+	}
+	return os.Stdout
+}
+
+// Atomic change of the writer for the given style; this overrides the
+// Writer default. A nil w reverts the style to that default.
+func WriterForStyle(style Style, w io.Writer) {
+	if style >= 0 && style < nStyles {
+		styleWriters[style].Store(box(w))
+	}
 }
 
 // Print style prefix, then args formated with fmt.Println.
 func (style Style) Log(args ...interface{}) error {
-	return style.log("", nil, args...)
+	return style.log(Info, 0, "", nil, args...)
 }
 
 // Print style prefix, then args formatted with fmt.Printf, and end with
 // newline.
 func (style Style) Logf(format string, args ...interface{}) error {
-	return style.log(format, nil, args...)
+	return style.log(Info, 0, format, nil, args...)
+}
+
+var styleNames = []string{
+	"NoOp",
+	"Plain",
+	"FileLine",
+	"Func",
+	"FileLineFunc",
+	"Time",
+	"TimeFileLine",
+	"JSON",
+	"ShortFunc",
+	"AbsFile",
+	"Pkg",
+	"ShortFile",
+	"Logfmt",
+}
+
+// Same as Log.
+func (style Style) Logln(args ...interface{}) error {
+	return style.log(Info, 0, "", nil, args...)
+}
+
+// Like Logf but without the trailing newline. Each call still prints the
+// style prefix, so, to build a line with several calls, use Plain, or an
+// fmt.Fprintf to the Writer, after the first.
+func (style Style) Logfnn(format string, args ...interface{}) error {
+	if style == NoOp {
+		return errarg(args)
+	}
+	l := Logger{style: style, partial: true}
+	return l.output(Info, 1, format, args)
+}
+
+// Like Log but the prefix reports the caller skip frames above the LogDepth
+// caller. Use this in wrappers, e.g. to report the caller of Debug with,
+//
+//	func Debug(args ...interface{}) { dbg.FileLine.LogDepth(1, args...) }
+func (style Style) LogDepth(skip int, args ...interface{}) error {
+	return style.log(Info, skip, "", nil, args...)
+}
+
+// Like Logf but the prefix reports the caller skip frames above the LogfDepth
+// caller.
+func (style Style) LogfDepth(skip int, format string,
+	args ...interface{}) error {
+	return style.log(Info, skip, format, nil, args...)
 }
 
 // Return name of style.
 func (style Style) String() string {
-	if style > nStyles {
+	if cs := style.custom(); cs != nil {
+		return cs.name
+	}
+	if style < 0 || style >= nStyles {
 		return fmt.Sprint(int(style))
 	}
-	return []string{
-		"NoOp",
-		"Plain",
-		"FileLine",
-		"Func",
-	}[style]
+	return styleNames[style]
+}
+
+// Return style of case-insensitive name, including those registered.
+func ParseStyle(name string) (Style, error) {
+	names := append(styleNames[:nStyles:nStyles], customStyleNames()...)
+	for i, s := range names {
+		if strings.EqualFold(name, s) {
+			return Style(i), nil
+		}
+	}
+	return NoOp, fmt.Errorf("dbg: unknown style %q, valid: %s", name,
+		strings.Join(names, ", "))
+}
+
+// Set style from case-insensitive name to satisfy flag.Value, e.g.
+//
+//	flag.Var(&Err, "dbg", "debug style")
+func (style *Style) Set(name string) error {
+	v, err := ParseStyle(name)
+	if err == nil {
+		*style = v
+	}
+	return err
 }
 
 // The unused arg is to work-around this vet false positive,
 //	call has arguments but no formatting directives
-func (style Style) log(format string, _ interface{}, args ...interface{}) error {
-	const skip = 2
-	if len(args) == 0 || args[0] == nil {
-		return nil
+func (style Style) log(level Level, depth int, format string, _ interface{},
+	args ...interface{}) error {
+	l := Logger{style: style}
+	return l.output(level, 2+depth, format, args)
+}
+
+// Return the arg of the SetErrorArgIndex, by default args[0], if it's an
+// error; otherwise, nil.
+func errarg(args []interface{}) error {
+	if i := errArgIndex(); i < len(args) {
+		if err, ok := args[i].(error); ok {
+			return err
+		}
+	}
+	return nil
+}
+
+// Return args formatted with fmt.Sprintf, or, without format,
+// fmt.Sprintln less newline.
+func message(format string, args []interface{}) string {
+	if len(format) > 0 {
+		return strict(format, len(args)) + fmt.Sprintf(format, args...)
+	}
+	s := fmt.Sprintln(args...)
+	return s[:len(s)-1]
+}
+
+// Atomic change of whether lines logging an error, i.e. args[0], are written
+// to the ErrorWriter, instead of the style's writer or default. This
+// doesn't affect a Logger with its own writer.
+func SetErrorsToStderr(enable bool) {
+	var v int32
+	if enable {
+		v = 1
+	}
+	atomic.StoreInt32(&errorsToErr, v)
+}
+
+// Atomic change of the os.Stderr default for SetErrorsToStderr.
+func ErrorWriter(w io.Writer) {
+	errorWriter.Store(box(w))
+}
+
+// Return the ErrorWriter, os.Stderr if unset.
+func CurrentErrorWriter() io.Writer {
+	if w := unbox(&errorWriter); w != nil {
+		return w
+	}
+	return os.Stderr
+}
+
+// Return the style's writer, or the default.
+func (style Style) dest() io.Writer {
+	if style >= 0 && style < nStyles {
+		if w := unbox(&styleWriters[style]); w != nil {
+			return w
+		}
 	}
-	err, ok := args[0].(error)
+	return CurrentWriter()
+}
+
+// Return whether the style prefix requires the caller frame.
+func (style Style) framed() bool {
+	return style > Plain && style != Time
+}
+
+// Return the frame of the caller skip frames above that of the caller call,
+// past any registered helpers.
+// Unlike runtime.FuncForPC, runtime.CallersFrames accounts for inlined calls
+// so, the function, file, and line are those of the logical caller.
+func caller(skip int) runtime.Frame {
+	pc, file, line, ok := callerFn(skip + 1)
 	if !ok {
-		err = nil
+		return runtime.Frame{}
 	}
-	if style == NoOp {
-		return err
-	}
-	w, ok := writer.Load().(io.Writer)
-	if !ok || w == nil {
-		w = os.Stdout
-	}
-	if style > Plain {
-		pc, file, line, ok := runtime.Caller(skip)
-		if !ok {
-			fmt.Fprintf(w, "pc[%#x] ", pc)
-		} else {
-			switch style {
-			case FileLine:
-				relfile, err := filepath.Rel(wd(), file)
-				if err != nil || relfile[0] == '.' {
-					relfile = relgopath(file)
-				}
-				fmt.Fprint(w, relfile, ":", line, ": ")
-			case Func:
-				name := runtime.FuncForPC(pc).Name()
-				fmt.Fprint(w, name, "() ")
+	frame := framepc(pc)
+	for helper(frame.Function) {
+		skip++
+		if pc, file, line, ok = callerFn(skip + 1); !ok {
+			return runtime.Frame{}
+		}
+		frame = framepc(pc)
+	}
+	frame.File, frame.Line = file, line
+	return frame
+}
+
+// The default callerFn.
+func callers1(skip int) (uintptr, string, int, bool) {
+	var pcs [1]uintptr
+	if runtime.Callers(skip+2, pcs[:]) == 0 {
+		return 0, "", 0, false
+	}
+	frame := framepc(pcs[0])
+	return pcs[0], frame.File, frame.Line, true
+}
+
+// Return the cached frame of a runtime.Callers program counter. Since each
+// logical frame, inlined or not, has its own pc, the cache has one entry per
+// call site.
+func framepc(pc uintptr) runtime.Frame {
+	if v, ok := frames.Load(pc); ok {
+		return v.(runtime.Frame)
+	}
+	frame := callersframe(pc)
+	frames.Store(pc, frame)
+	return frame
+}
+
+func callersframe(pc uintptr) runtime.Frame {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	return frame
+}
+
+// Return style prefix of the given caller frame.
+func (style Style) prefix(frame runtime.Frame) string {
+	var s string
+	if style == Time || style == TimeFileLine {
+		s = timestamp() + " "
+	}
+	if !style.framed() {
+		return s
+	}
+	if cs := style.custom(); cs != nil {
+		return s + cs.sprefix(frame.PC, frame.File, frame.Line)
+	}
+	if len(frame.File) == 0 {
+		return s + fmt.Sprintf("pc[%#x] ", frame.PC)
+	}
+	switch style {
+	case FileLine, TimeFileLine:
+		s += align(fmt.Sprint(relfile(frame.File), ":", frame.Line, ":")) +
+			" "
+	case Func:
+		s += fmt.Sprint(frame.Function, "() ")
+	case ShortFunc:
+		s += fmt.Sprint(shortfunc(frame.Function), "() ")
+	case FileLineFunc:
+		s += fmt.Sprint(frame.Function, "() ") +
+			align(fmt.Sprint(relfile(frame.File), ":", frame.Line, ":")) +
+			" "
+	case AbsFile:
+		s += align(fmt.Sprint(abstrimfile(frame.File), ":", frame.Line,
+			":")) + " "
+	case Pkg:
+		s += fmt.Sprint(pkgname(frame.Function), ": ")
+	case ShortFile:
+		s += align(fmt.Sprint(filepath.Base(frame.File), ":", frame.Line,
+			":")) + " "
+	}
+	return s
+}
+
+// Trim the import path directory from a qualified function name, e.g.
+//
+//	github.com/platinasystems/dbg.(*T).M.func1 -> dbg.(*T).M.func1
+func shortfunc(name string) string {
+	end := strings.IndexByte(name, '[')
+	if end < 0 {
+		end = len(name)
+	}
+	name = name[strings.LastIndexByte(name[:end], '/')+1:]
+	if dot := strings.IndexByte(name, '.'); dot >= 0 {
+		return unescape(name[:dot]) + name[dot:]
+	}
+	return name
+}
+
+// Return the import path of a qualified function name, e.g.
+//
+//	github.com/platinasystems/dbg.(*T).M.func1 -> github.com/platinasystems/dbg
+func pkgname(name string) string {
+	end := strings.IndexByte(name, '[')
+	if end < 0 {
+		end = len(name)
+	}
+	slash := strings.LastIndexByte(name[:end], '/') + 1
+	if dot := strings.IndexByte(name[slash:end], '.'); dot >= 0 {
+		return unescape(name[:slash+dot])
+	}
+	return unescape(name[:end])
+}
+
+// Undo the runtime's %xx escape of the dots and such in the last element of
+// an import path, e.g.
+//
+//	gopkg.in/yaml%2ev3 -> gopkg.in/yaml.v3
+func unescape(pkg string) string {
+	if strings.IndexByte(pkg, '%') < 0 {
+		return pkg
+	}
+	var sb strings.Builder
+	for i := 0; i < len(pkg); i++ {
+		if pkg[i] == '%' && i+2 < len(pkg) {
+			b, err := strconv.ParseUint(pkg[i+1:i+3], 16, 8)
+			if err == nil {
+				sb.WriteByte(byte(b))
+				i += 2
+				continue
 			}
 		}
+		sb.WriteByte(pkg[i])
 	}
-	if len(format) > 0 {
-		fmt.Fprintf(w, format, args...)
-		fmt.Fprintln(w)
-	} else {
-		fmt.Fprintln(w, args...)
+	return sb.String()
+}
+
+// Atomic change of whether the working directory, GOPATH, and GOPATH src
+// directories are cached. The default, true, reads each once; with false,
+// these are read for every FileLine path, e.g. for a tool that changes
+// them as it runs.
+func SetCaching(enable bool) {
+	var v int32
+	if !enable {
+		v = 1
 	}
-	return err
+	atomic.StoreInt32(&nocache, v)
+}
+
+func caching() bool {
+	return atomic.LoadInt32(&nocache) == 0
 }
 
 func gopath() string {
+	if !caching() {
+		return getgopath()
+	}
 	cached.gopath.once.Do(func() {
-		s := os.Getenv("GOPATH")
-		if len(s) == 0 {
-			s = build.Default.GOPATH
-		}
-		cached.gopath.val = s
+		cached.gopath.val = getgopath()
 	})
 	return cached.gopath.val.(string)
 }
 
-func gopathsrc() string {
-	cached.gopathsrc.once.Do(func() {
-		cached.gopathsrc.val = filepath.Join(gopath(), "src")
+func getgopath() string {
+	if s := os.Getenv("GOPATH"); len(s) > 0 {
+		return s
+	}
+	return build.Default.GOPATH
+}
+
+// Return the src directory of each GOPATH list entry.
+func gopathsrcs() []string {
+	if !caching() {
+		return getgopathsrcs()
+	}
+	cached.gopathsrcs.once.Do(func() {
+		cached.gopathsrcs.val = getgopathsrcs()
 	})
-	return cached.gopathsrc.val.(string)
+	return cached.gopathsrcs.val.([]string)
 }
 
-func relgopath(path string) string {
-	s, err := filepath.Rel(gopathsrc(), path)
-	if err != nil {
-		s = path
+func getgopathsrcs() []string {
+	var srcs []string
+	for _, dir := range filepath.SplitList(gopath()) {
+		srcs = append(srcs, filepath.Join(dir, "src"))
+	}
+	return srcs
+}
+
+// Atomic change of the directory that FileLine paths are relative to; an
+// empty dir restores the default, the working directory.
+func SetPathBase(dir string) {
+	pathBase.Store(dir)
+}
+
+// Atomic change of the directory prefixes that FileLine and AbsFile styles
+// strip from the cleaned, absolute path of a caller's file. The first
+// matching prefix wins, so list more specific prefixes first. With none,
+// the styles resolve the path as usual.
+func SetTrimPrefixes(prefixes ...string) {
+	var list []string
+	for _, prefix := range prefixes {
+		if len(prefix) > 0 {
+			list = append(list, filepath.Clean(absfile(prefix)))
+		}
+	}
+	trimPrefixes.Store(list)
+}
+
+// Return the slash separated path less the first matching SetTrimPrefixes
+// prefix and true; or, if none match, an empty string and false.
+func trimfile(path string) (string, bool) {
+	list, _ := trimPrefixes.Load().([]string)
+	if len(list) == 0 {
+		return "", false
+	}
+	path = filepath.Clean(absfile(path))
+	for _, prefix := range list {
+		if !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		s := path[len(prefix):]
+		if len(s) > 0 && os.IsPathSeparator(s[0]) {
+			return filepath.ToSlash(s[1:]), true
+		} else if os.IsPathSeparator(prefix[len(prefix)-1]) {
+			return filepath.ToSlash(s), true
+		}
+	}
+	return "", false
+}
+
+// Return the SetPathBase directory or the working directory.
+func base() string {
+	if s, _ := pathBase.Load().(string); len(s) > 0 {
+		return s
+	}
+	return wd()
+}
+
+// Return the slash separated path relative to the base directory or, if
+// outside of that, "std:" and the path relative to GOROOT/src, or the module
+// import path of the file, or, if not within a module, relative to
+// GOPATH/src.
+func relfile(path string) string {
+	if s, ok := trimfile(path); ok {
+		return s
+	}
+	s, ok := relbase(path)
+	if !ok {
+		if real := realpath(path); real != path {
+			s, ok = relbase(real)
+			path = real
+		}
+	}
+	if !ok {
+		if s = relgoroot(path); len(s) == 0 {
+			if s = relmodule(path); len(s) == 0 {
+				s = relgopath(path)
+			}
+		}
+	}
+	return unvendor(filepath.ToSlash(s))
+}
+
+// Return path relative to the base directory and whether it's within.
+func relbase(path string) (string, bool) {
+	s, err := relpath(base(), path)
+	if err != nil || len(s) == 0 || s[0] == '.' {
+		return "", false
 	}
+	return s, true
+}
+
+// Return path with symbolic links resolved, or, on error, as is. Since
+// EvalSymlinks stats each element of the path, the result is cached unless
+// SetCaching(false); so, only the first line of each file pays that cost.
+func realpath(path string) string {
+	if !caching() {
+		return evalsymlinks(path)
+	}
+	if v, ok := realpaths.Load(path); ok {
+		return v.(string)
+	}
+	s := evalsymlinks(path)
+	realpaths.Store(path, s)
 	return s
 }
 
-func wd() string {
-	cached.wd.once.Do(func() {
-		s, err := os.Getwd()
-		if err != nil {
-			s = "."
+func evalsymlinks(path string) string {
+	if s, err := filepath.EvalSymlinks(path); err == nil {
+		return s
+	}
+	return path
+}
+
+// Return the slash separated path less everything through its last vendor
+// directory, i.e. the import path of the vendored file.
+func unvendor(path string) string {
+	if i := strings.LastIndex(path, "/vendor/"); i >= 0 {
+		return path[i+len("/vendor/"):]
+	}
+	return strings.TrimPrefix(path, "vendor/")
+}
+
+// Return "std:" and path relative to GOROOT/src or, if outside of that, an
+// empty string.
+func relgoroot(path string) string {
+	cached.gorootsrc.once.Do(func() {
+		var s string
+		if len(build.Default.GOROOT) > 0 {
+			s = filepath.Join(build.Default.GOROOT, "src")
 		}
-		cached.wd.val = s
+		cached.gorootsrc.val = s
 	})
-	return cached.wd.val.(string)
+	src := cached.gorootsrc.val.(string)
+	if len(src) == 0 {
+		return ""
+	}
+	s, err := filepath.Rel(src, path)
+	if err != nil || s == ".." ||
+		strings.HasPrefix(s, ".."+string(filepath.Separator)) {
+		return ""
+	}
+	return "std:" + s
+}
+
+// Return the path less the first matching SetTrimPrefixes prefix or, if
+// none match, the absolute path.
+func abstrimfile(path string) string {
+	if s, ok := trimfile(path); ok {
+		return s
+	}
+	return absfile(path)
+}
+
+// Return the absolute path, unchanged if already so.
+func absfile(path string) string {
+	if !filepath.IsAbs(path) {
+		if s, err := filepath.Abs(path); err == nil {
+			return s
+		}
+	}
+	return path
+}
+
+// Return path relative to the src directory of the first GOPATH entry that
+// contains it.
+func relgopath(path string) string {
+	return relsrc(gopathsrcs(), path)
+}
+
+// Return path relative to the first of srcs that contains it; or, if none
+// do, relative to the first src; or, if on another volume, like a
+// different Windows drive, its base name.
+func relsrc(srcs []string, path string) string {
+	var first string
+	for i, src := range srcs {
+		s, err := filepath.Rel(src, path)
+		if err != nil {
+			continue
+		}
+		if s != ".." &&
+			!strings.HasPrefix(s, ".."+string(filepath.Separator)) {
+			return s
+		}
+		if i == 0 {
+			first = s
+		}
+	}
+	if len(first) > 0 {
+		return first
+	}
+	if vol := filepath.VolumeName(path); len(vol) > 0 {
+		for _, src := range srcs {
+			if strings.EqualFold(vol, filepath.VolumeName(src)) {
+				return path
+			}
+		}
+		return filepath.Base(path)
+	}
+	return path
+}
+
+func wd() string {
+	if !caching() {
+		return getwd()
+	}
+	if s, ok := cached.wd.Load().(string); ok {
+		return s
+	}
+	return RefreshWorkingDir()
+}
+
+// Re-read the cached working directory, e.g. after os.Chdir, and return it.
+func RefreshWorkingDir() string {
+	s := getwd()
+	cached.wd.Store(s)
+	return s
+}
+
+func getwd() string {
+	s, err := os.Getwd()
+	if err != nil {
+		return "."
+	}
+	return s
 }
@@ -0,0 +1,33 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRelFileDrive(t *testing.T) {
+	drive := "Y:"
+	if strings.EqualFold(filepath.VolumeName(wd()), drive) {
+		drive = "Z:"
+	}
+	file := drive + `\proj\pkg\file.go`
+	if got := relfile(file); got != "file.go" {
+		t.Errorf("relfile(%q) = %q, want file.go", file, got)
+	}
+}
+
+func TestRelFileSlash(t *testing.T) {
+	file := filepath.Join(wd(), "sub", "file.go")
+	if got := relfile(file); got != "sub/file.go" {
+		t.Errorf("relfile(%q) = %q, want sub/file.go", file, got)
+	}
+	file = filepath.Join(gopathsrcs()[0], "example.com", "pkg", "file.go")
+	if got := relgopath(file); got != `example.com\pkg\file.go` {
+		t.Errorf("relgopath(%q) = %q", file, got)
+	}
+}
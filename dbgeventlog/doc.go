@@ -0,0 +1,18 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package dbgeventlog provides a Windows Event Log writer for dbg. It's a
+separate module so that dbg itself doesn't depend on golang.org/x/sys.
+
+Usage:
+
+	w, err := dbgeventlog.NewEventLogWriter("myservice")
+	if err != nil {
+		return err
+	}
+	dbg.RegisterCloser(w)
+	dbg.Writer(w)
+*/
+package dbgeventlog
@@ -0,0 +1,17 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows
+
+package dbgeventlog
+
+import (
+	"errors"
+	"io"
+)
+
+// The Windows Event Log isn't available on this platform.
+func NewEventLogWriter(source string) (io.WriteCloser, error) {
+	return nil, errors.New("dbgeventlog: event log not implemented")
+}
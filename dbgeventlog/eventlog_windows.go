@@ -0,0 +1,75 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+
+package dbgeventlog
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/sys/windows/svc/eventlog"
+)
+
+// The event ID of each line.
+const eventID = 1
+
+type eventLogWriter struct {
+	mu  sync.Mutex
+	log *eventlog.Log
+}
+
+// Return a writer to the Windows Event Log of the given, registered source
+// for use with dbg.Writer. Error lines are Error events, Warn lines are
+// Warning events, and all others, including those written directly, are
+// Information events. If an event fails, the writer copies the line to
+// os.Stderr instead.
+func NewEventLogWriter(source string) (io.WriteCloser, error) {
+	log, err := eventlog.Open(source)
+	if err != nil {
+		return nil, err
+	}
+	return &eventLogWriter{log: log}, nil
+}
+
+func (w *eventLogWriter) Write(b []byte) (int, error) {
+	return w.WriteLevel("Info", string(b))
+}
+
+// Write the line as an event of the dbg level's type.
+func (w *eventLogWriter) WriteLevel(level, line string) (int, error) {
+	if err := w.report(level, strings.TrimRight(line, "\r\n")); err != nil {
+		return os.Stderr.WriteString(line)
+	}
+	return len(line), nil
+}
+
+func (w *eventLogWriter) report(level, msg string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.log == nil {
+		return os.ErrClosed
+	}
+	switch level {
+	case "Error":
+		return w.log.Error(eventID, msg)
+	case "Warn":
+		return w.log.Warning(eventID, msg)
+	}
+	return w.log.Info(eventID, msg)
+}
+
+func (w *eventLogWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.log == nil {
+		return os.ErrClosed
+	}
+	err := w.log.Close()
+	w.log = nil
+	return err
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows
+
+package dbgeventlog
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/windows/svc/eventlog"
+)
+
+const testSource = "dbgeventlog-test"
+
+func TestEventLogWriter(t *testing.T) {
+	err := eventlog.InstallAsEventCreate(testSource,
+		eventlog.Error|eventlog.Warning|eventlog.Info)
+	if err != nil {
+		t.Skip("install event source, requires administrator:", err)
+	}
+	defer eventlog.Remove(testSource)
+	w, err := NewEventLogWriter(testSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lw := w.(interface {
+		WriteLevel(level, line string) (int, error)
+	})
+	nonce := fmt.Sprint(time.Now().UnixNano())
+	for _, level := range []string{"Debug", "Info", "Warn", "Error"} {
+		line := level + " " + nonce + "\n"
+		if n, err := lw.WriteLevel(level, line); n != len(line) ||
+			err != nil {
+			t.Errorf("WriteLevel(%q) got %d, %v", level, n, err)
+		}
+	}
+	if n, err := w.Write([]byte("direct " + nonce + "\n")); err != nil ||
+		n != len("direct "+nonce+"\n") {
+		t.Errorf("Write got %d, %v", n, err)
+	}
+	if err = w.Close(); err != nil {
+		t.Error(err)
+	}
+	if err = w.Close(); err != os.ErrClosed {
+		t.Errorf("second Close got %v", err)
+	}
+	events := recentEvents(t, testSource, 10)
+	// Event levels: 2 Error, 3 Warning, 4 Information
+	for _, x := range []struct{ msg, level string }{
+		{"Debug " + nonce, "<Level>4</Level>"},
+		{"Info " + nonce, "<Level>4</Level>"},
+		{"Warn " + nonce, "<Level>3</Level>"},
+		{"Error " + nonce, "<Level>2</Level>"},
+		{"direct " + nonce, "<Level>4</Level>"},
+	} {
+		found := false
+		for _, event := range events {
+			if strings.Contains(event, "<Data>"+x.msg+"</Data>") {
+				found = true
+				if !strings.Contains(event, x.level) {
+					t.Errorf("%q event without %s", x.msg, x.level)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%q event not found", x.msg)
+		}
+	}
+}
+
+// Return the XML of the source's newest Application log events.
+func recentEvents(t *testing.T, source string, n int) []string {
+	query := fmt.Sprintf("*[System[Provider[@Name='%s']]]", source)
+	out, err := exec.Command("wevtutil", "qe", "Application",
+		"/q:"+query, fmt.Sprint("/c:", n), "/rd:true",
+		"/f:xml").Output()
+	if err != nil {
+		t.Fatal("wevtutil:", err)
+	}
+	return strings.SplitAfter(string(out), "</Event>")
+}
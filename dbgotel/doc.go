@@ -0,0 +1,15 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+Package dbgotel provides an OpenTelemetry context extractor for dbg.LogCtx.
+It's a separate module so that dbg itself doesn't depend on OpenTelemetry.
+
+Usage:
+
+	dbg.RegisterContextExtractor(dbgotel.OTelExtractor)
+	...
+	dbg.Plain.LogCtx(ctx, "sent") // sent trace_id=... span_id=...
+*/
+package dbgotel
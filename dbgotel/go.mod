@@ -0,0 +1,7 @@
+module github.com/platinasystems/dbg/dbgotel
+
+go 1.21
+
+require go.opentelemetry.io/otel/trace v1.28.0
+
+require go.opentelemetry.io/otel v1.28.0 // indirect
@@ -0,0 +1,24 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbgotel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// A dbg.ContextExtractor of the "trace_id" and "span_id" fields of the
+// context's span; or nothing, without a valid span.
+func OTelExtractor(ctx context.Context) []interface{} {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return []interface{}{
+		"trace_id", sc.TraceID().String(),
+		"span_id", sc.SpanID().String(),
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbgotel
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestOTelExtractor(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 15: 0x36},
+		SpanID:  trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 7: 0xb7},
+	})
+	for _, x := range []struct {
+		name string
+		ctx  context.Context
+		want []interface{}
+	}{
+		{"span", trace.ContextWithSpanContext(context.Background(), sc),
+			[]interface{}{
+				"trace_id", "4bf92f35000000000000000000000036",
+				"span_id", "00f067aa000000b7",
+			}},
+		{"without", context.Background(), nil},
+		{"invalid", trace.ContextWithSpanContext(context.Background(),
+			trace.SpanContext{}), nil},
+	} {
+		if got := OTelExtractor(x.ctx); !reflect.DeepEqual(got, x.want) {
+			t.Errorf("%s got %q, want %q", x.name, got, x.want)
+		}
+	}
+}
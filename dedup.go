@@ -0,0 +1,77 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	dedupEnabled int32
+	dedup        struct {
+		sync.Mutex
+		valid bool
+		msg   string
+		n     int
+		style Style
+		level Level
+		frame runtime.Frame
+		w     io.Writer
+	}
+)
+
+// Atomic change of whether consecutive identical messages from the same call
+// site are dropped. The next distinct message is preceded by
+//
+//	(previous message repeated N times)
+//
+// with the style and writer of the repeated message. Disabling forgets any
+// uncounted repeats.
+func SetDedup(enable bool) {
+	var v int32
+	if enable {
+		v = 1
+	}
+	dedup.Lock()
+	dedup.valid = false
+	dedup.n = 0
+	dedup.w = nil
+	atomic.StoreInt32(&dedupEnabled, v)
+	dedup.Unlock()
+}
+
+func deduping() bool {
+	return atomic.LoadInt32(&dedupEnabled) != 0
+}
+
+// Return whether the message repeats the last; otherwise, write the count of
+// any repeats of the last and remember this one.
+func (l *Logger) repeated(level Level, frame runtime.Frame, w io.Writer,
+	msg string) bool {
+	dedup.Lock()
+	defer dedup.Unlock()
+	if dedup.valid && frame.PC == dedup.frame.PC && msg == dedup.msg {
+		dedup.n++
+		return true
+	}
+	if dedup.n > 0 {
+		prev := Logger{style: dedup.style}
+		line, _ := prev.sprint(dedup.level, dedup.frame,
+			colorize(dedup.w), "(previous message repeated %d times)",
+			nil, []interface{}{dedup.n})
+		io.WriteString(dedup.w, line)
+	}
+	dedup.valid = true
+	dedup.msg = msg
+	dedup.n = 0
+	dedup.style = l.style
+	dedup.level = level
+	dedup.frame = frame
+	dedup.w = w
+	return false
+}
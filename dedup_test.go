@@ -0,0 +1,38 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDedup(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	SetDedup(true)
+	defer SetDedup(false)
+	for i := 0; i < 3; i++ {
+		Plain.Log("flap")
+	}
+	// distinct call sites
+	Plain.Log("steady")
+	Plain.Log("steady")
+	for i := 0; i < 2; i++ {
+		Plain.Log("steady")
+	}
+	Plain.Log("done")
+	want := "flap\n" +
+		"(previous message repeated 2 times)\n" +
+		"steady\n" +
+		"steady\n" +
+		"steady\n" +
+		"(previous message repeated 1 times)\n" +
+		"done\n"
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+var defaultLogger struct {
+	sync.Mutex
+	l atomic.Value
+}
+
+// The default logger before any change, of FileLine style.
+var initialLogger = Logger{style: FileLine}
+
+// Return the logger of the package level Log, Logf, and V; initially that of
+// the DBG_STYLE named style, else FileLine, with its writer and no prefix.
+// Precedence: SetDefaultStyle overrides DBG_STYLE, which overrides FileLine.
+func DefaultLogger() *Logger {
+	if l, ok := defaultLogger.l.Load().(*Logger); ok {
+		return l
+	}
+	return &initialLogger
+}
+
+// Atomic change of the package level Log and Logf style, e.g.
+//
+//	dbg.SetDefaultStyle(dbg.NoOp)
+func SetDefaultStyle(style Style) {
+	setDefault(func(l *Logger) { l.style = style })
+}
+
+// Atomic change of the package level Log and Logf writer; nil, including a
+// typed nil pointer, reverts to that of the default style.
+func SetDefaultWriter(w io.Writer) {
+	setDefault(func(l *Logger) { l.w = box(w).Writer })
+}
+
+// Atomic change of the package level Log and Logf message prefix.
+func SetDefaultPrefix(p string) {
+	setDefault(func(l *Logger) { l.prefix = p })
+}
+
+func setDefault(f func(*Logger)) {
+	defaultLogger.Lock()
+	defer defaultLogger.Unlock()
+	l := DefaultLogger().Clone()
+	f(l)
+	defaultLogger.l.Store(l)
+}
+
+// Like Style.Log but with the default logger's style, writer, and prefix.
+func Log(args ...interface{}) error {
+	return DefaultLogger().output(Info, 1, "", args)
+}
+
+// Like Style.Logf but with the default logger's style, writer, and prefix.
+func Logf(format string, args ...interface{}) error {
+	return DefaultLogger().output(Info, 1, format, args)
+}
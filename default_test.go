@@ -0,0 +1,34 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestDefaultLogger(t *testing.T) {
+	defer func(l *Logger) { defaultLogger.l.Store(l) }(DefaultLogger())
+	buf := new(bytes.Buffer)
+	SetDefaultStyle(FileLine)
+	SetDefaultWriter(buf)
+	_, _, line, _ := runtime.Caller(0)
+	Log("one")
+	SetDefaultStyle(Plain)
+	SetDefaultPrefix("[pkg] ")
+	Logf("%s", "two")
+	if err := Log(os.ErrInvalid); err != os.ErrInvalid {
+		t.Error("Log didn't return error")
+	}
+	SetDefaultStyle(NoOp)
+	Log("three")
+	if got, want := buf.String(), fmt.Sprint("default_test.go:", line+1,
+		": one\n[pkg] two\n[pkg] invalid argument\n"); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
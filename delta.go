@@ -0,0 +1,57 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	showDelta int32
+	deltas    struct {
+		sync.Mutex
+		last map[uintptr]time.Time
+	}
+)
+
+// Atomic change of whether each prefix ends with the time since the last
+// line from the same call site, e.g. "(+2.3ms) ", or "(+0s) " for the first.
+// This doesn't apply to the JSON and Logfmt styles.
+func SetShowDelta(enable bool) {
+	var v int32
+	if enable {
+		v = 1
+	}
+	atomic.StoreInt32(&showDelta, v)
+}
+
+func deltaing() bool {
+	return atomic.LoadInt32(&showDelta) != 0
+}
+
+// Return the delta prefix of the call site; unless dry, e.g. for Sprint,
+// this also records the time of its line.
+func deltaPrefix(pc uintptr, dry bool) string {
+	if !deltaing() {
+		return ""
+	}
+	now := nowFunc()
+	deltas.Lock()
+	last, found := deltas.last[pc]
+	if !dry {
+		if deltas.last == nil {
+			deltas.last = make(map[uintptr]time.Time)
+		}
+		deltas.last[pc] = now
+	}
+	deltas.Unlock()
+	var d time.Duration
+	if found {
+		d = now.Sub(last)
+	}
+	return "(+" + d.String() + ") "
+}
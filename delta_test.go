@@ -0,0 +1,68 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestShowDelta(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	defer SetShowDelta(false)
+	SetShowDelta(true)
+	deltas.Lock()
+	deltas.last = nil
+	deltas.Unlock()
+	t0 := time.Date(2018, 10, 23, 10, 4, 5, 0, time.UTC)
+	defer fakeNow(t0)()
+	for _, d := range []time.Duration{
+		0,
+		2300 * time.Microsecond,
+		2300*time.Microsecond + time.Second,
+	} {
+		nowFunc = func() time.Time { return t0.Add(d) }
+		Plain.Log("loop")
+	}
+	Plain.Log("other")
+	SetShowDelta(false)
+	Plain.Log("off")
+	want := "(+0s) loop\n" +
+		"(+2.3ms) loop\n" +
+		"(+1s) loop\n" +
+		"(+0s) other\n" +
+		"off\n"
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestDeltaDry(t *testing.T) {
+	defer SetShowDelta(false)
+	SetShowDelta(true)
+	deltas.Lock()
+	deltas.last = nil
+	deltas.Unlock()
+	t0 := time.Date(2018, 10, 23, 10, 4, 5, 0, time.UTC)
+	defer fakeNow(t0)()
+	for i, x := range []struct {
+		dry  bool
+		want string
+	}{
+		{true, "(+0s) "},
+		{false, "(+0s) "},
+		{true, "(+1s) "},
+		{false, "(+2s) "},
+	} {
+		now := t0.Add(time.Duration(i) * time.Second)
+		nowFunc = func() time.Time { return now }
+		if got := deltaPrefix(1, x.dry); got != x.want {
+			t.Errorf("[%d] got %q, want %q", i, got, x.want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+func depthLog(args ...interface{}) error {
+	return FileLine.LogDepth(1, args...)
+}
+
+func depthLogf(format string, args ...interface{}) error {
+	return FileLine.LogfDepth(1, format, args...)
+}
+
+func depthLogf2(format string, args ...interface{}) error {
+	return depthLogf2a(format, args...)
+}
+
+func depthLogf2a(format string, args ...interface{}) error {
+	return FileLine.LogfDepth(2, format, args...)
+}
+
+func TestLogDepth(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	_, _, line, _ := runtime.Caller(0)
+	depthLog("one")
+	depthLogf("%s", "onef")
+	depthLogf2("%s", "two")
+	FileLine.LogDepth(0, "zero")
+	want := fmt.Sprint("depth_test.go:", line+1, ": one\n",
+		"depth_test.go:", line+2, ": onef\n",
+		"depth_test.go:", line+3, ": two\n",
+		"depth_test.go:", line+4, ": zero\n")
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// Small enough for the compiler to inline.
+func inlinedLog(s string) {
+	FileLineFunc.LogDepth(1, s)
+}
+
+func TestInlinedCaller(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	_, _, line, _ := runtime.Caller(0)
+	inlinedLog("inlined")
+	func() { FileLineFunc.Log("closure") }()
+	want := fmt.Sprint("github.com/platinasystems/dbg.TestInlinedCaller() ",
+		"depth_test.go:", line+1, ": inlined\n",
+		"github.com/platinasystems/dbg.TestInlinedCaller.func1() ",
+		"depth_test.go:", line+2, ": closure\n")
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFramePC(t *testing.T) {
+	var pcs [1]uintptr
+	runtime.Callers(1, pcs[:])
+	want := "github.com/platinasystems/dbg.TestFramePC"
+	for i := 0; i < 2; i++ {
+		frame := framepc(pcs[0])
+		if frame.Function != want {
+			t.Fatalf("got %q, want %q", frame.Function, want)
+		}
+		if frame != callersframe(pcs[0]) {
+			t.Fatal("cached frame differs")
+		}
+	}
+}
+
+func BenchmarkFramePCCached(b *testing.B) {
+	var pcs [1]uintptr
+	runtime.Callers(1, pcs[:])
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		framepc(pcs[0])
+	}
+}
+
+func BenchmarkFramePCUncached(b *testing.B) {
+	var pcs [1]uintptr
+	runtime.Callers(1, pcs[:])
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		callersframe(pcs[0])
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Print style prefix and length followed by hex.Dump of b, e.g.
+//
+//	dump_test.go:22: 5 bytes
+//	00000000  68 65 6c 6c 6f                                    |hello|
+func (style Style) Dump(b []byte) {
+	style.dump(b, len(b))
+}
+
+// Like Dump but only the first max bytes followed by a notice of the
+// number omitted.
+func (style Style) DumpN(b []byte, max int) {
+	style.dump(b, max)
+}
+
+func (style Style) dump(b []byte, max int) {
+	if style == NoOp {
+		return
+	}
+	if max < 0 {
+		max = 0
+	}
+	var sb strings.Builder
+	fmt.Fprint(&sb, len(b), " bytes")
+	if len(b) > 0 {
+		sb.WriteByte('\n')
+		if max < len(b) {
+			sb.WriteString(hex.Dump(b[:max]))
+			fmt.Fprintf(&sb, "... (%d more bytes)", len(b)-max)
+		} else {
+			sb.WriteString(strings.TrimSuffix(hex.Dump(b), "\n"))
+		}
+	}
+	l := Logger{style: style}
+	l.output(Info, 2, "", []interface{}{sb.String()})
+}
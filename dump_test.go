@@ -0,0 +1,42 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+func TestDump(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	b := []byte("hello, wire protocol\x00\x01")
+	_, _, line, _ := runtime.Caller(0)
+	FileLine.Dump(b)
+	want := fmt.Sprint("dump_test.go:", line+1, ": ", len(b), " bytes\n",
+		hex.Dump(b))
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	buf.Reset()
+	Plain.DumpN(b, 4)
+	want = fmt.Sprint(len(b), " bytes\n", hex.Dump(b[:4]),
+		"... (", len(b)-4, " more bytes)\n")
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	buf.Reset()
+	Plain.Dump(nil)
+	NoOp.Dump(b)
+	if got, want := buf.String(), "0 bytes\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import "sync/atomic"
+
+var disabled int32
+
+// Atomic change of whether any style prints; while disabled, every style
+// behaves like NoOp, still returning args[0] if it's an error. Use this to
+// silence a noisy phase without changing style variables.
+func SetEnabled(enable bool) {
+	var v int32
+	if !enable {
+		v = 1
+	}
+	atomic.StoreInt32(&disabled, v)
+}
+
+func enabled() bool {
+	return atomic.LoadInt32(&disabled) == 0
+}
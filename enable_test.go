@@ -0,0 +1,36 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestSetEnabled(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	defer SetEnabled(true)
+	Plain.Log("before")
+	SetEnabled(false)
+	Plain.Log("disabled")
+	Plain.Logf("%s", "disabled")
+	if err := Plain.Log(os.ErrInvalid); err != os.ErrInvalid {
+		t.Error("disabled didn't return error")
+	}
+	if Plain.Enabled() {
+		t.Error("Enabled while disabled")
+	}
+	if s := Plain.Sprint("disabled"); s != "" {
+		t.Errorf("Sprint while disabled got %q", s)
+	}
+	SetEnabled(true)
+	Plain.Log("after")
+	if got, want := buf.String(), "before\nafter\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
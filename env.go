@@ -0,0 +1,37 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import "os"
+
+// Default is NoOp unless the DBG_STYLE environment variable names a valid
+// style at program init. Packages may adopt it for their style variables,
+//
+//	var Err = dbg.Default
+//
+// Precedence: an assignment by the program overrides Default, which
+// overrides the NoOp zero value.
+var Default Style
+
+func init() {
+	InitFromEnv()
+}
+
+// Set Default and the DefaultLogger style from the DBG_STYLE environment
+// variable. These are unchanged if DBG_STYLE is unset or names an unknown
+// style; the latter returns the ParseStyle error.
+func InitFromEnv() error {
+	name, ok := os.LookupEnv("DBG_STYLE")
+	if !ok {
+		return nil
+	}
+	style, err := ParseStyle(name)
+	if err != nil {
+		return err
+	}
+	Default = style
+	SetDefaultStyle(style)
+	return nil
+}
@@ -0,0 +1,30 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import "testing"
+
+func TestInitFromEnv(t *testing.T) {
+	defer func(style Style) { Default = style }(Default)
+	defer func(l *Logger) { defaultLogger.l.Store(l) }(DefaultLogger())
+	Default = NoOp
+	t.Setenv("DBG_STYLE", "func")
+	if err := InitFromEnv(); err != nil {
+		t.Fatal(err)
+	}
+	if Default != Func {
+		t.Fatal("Default:", Default)
+	}
+	if style := DefaultLogger().style; style != Func {
+		t.Fatal("DefaultLogger style:", style)
+	}
+	t.Setenv("DBG_STYLE", "bogus")
+	if err := InitFromEnv(); err == nil {
+		t.Fatal("no error for bogus DBG_STYLE")
+	}
+	if Default != Func {
+		t.Fatal("bogus DBG_STYLE changed Default to", Default)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+var errorArgIndex int32
+
+// Atomic change of which arg, if an error, Log and Logf return instead of
+// args[0], e.g. 1 for Log("open", err). A negative i restores the default,
+// 0. This doesn't change that a nil args[0] prints nothing.
+func SetErrorArgIndex(i int) {
+	if i < 0 {
+		i = 0
+	}
+	atomic.StoreInt32(&errorArgIndex, int32(i))
+}
+
+func errArgIndex() int {
+	return int(atomic.LoadInt32(&errorArgIndex))
+}
+
+// Like Log(err, context...) but regardless of SetErrorArgIndex, this logs
+// and returns err; if nil, this prints nothing and returns nil.
+func (style Style) LogErr(err error, context ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return style.logerr(err, append([]interface{}{err}, context...))
+}
+
+// Like LogErr but with the context before err, e.g.
+//
+//	return dbg.Err.LogIfErr(f.Close(), "close", f.Name())
+//
+// If err is nil, this prints nothing and returns nil.
+func (style Style) LogIfErr(err error, context ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return style.logerr(err, append(context[:len(context):len(context)],
+		err))
+}
+
+// Write args with the frame of the caller of the logerr caller.
+func (style Style) logerr(err error, args []interface{}) error {
+	if !style.Enabled() {
+		return err
+	}
+	l := Logger{style: style}
+	var frame runtime.Frame
+	if l.framed() {
+		frame = caller(2)
+	}
+	l.write(Info, frame, "", err, args)
+	return err
+}
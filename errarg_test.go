@@ -0,0 +1,88 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestLogErr(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	defer SetErrorArgIndex(0)
+	SetErrorArgIndex(2)
+	if err := ShortFile.LogErr(os.ErrInvalid, "opening", "config"); err !=
+		os.ErrInvalid {
+		t.Errorf("got %v, want %v", err, os.ErrInvalid)
+	}
+	_, _, line, _ := runtime.Caller(0)
+	if err := Plain.LogErr(nil, "nothing"); err != nil {
+		t.Errorf("nil got %v", err)
+	}
+	if err := NoOp.LogErr(os.ErrInvalid); err != os.ErrInvalid {
+		t.Errorf("NoOp got %v", err)
+	}
+	want := fmt.Sprint("errarg_test.go:", line-4,
+		": invalid argument opening config\n")
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestErrorArgIndex(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	defer SetErrorArgIndex(0)
+	if err := Plain.Log("open", os.ErrNotExist); err != nil {
+		t.Errorf("index 0 got %v", err)
+	}
+	SetErrorArgIndex(1)
+	if err := Plain.Log("open", os.ErrNotExist); err != os.ErrNotExist {
+		t.Errorf("index 1 got %v", err)
+	}
+	if err := Plain.Log(os.ErrInvalid); err != nil {
+		t.Errorf("index 1 of one arg got %v", err)
+	}
+	if err := Plain.Logf("%s: %v", "open", os.ErrNotExist); err !=
+		os.ErrNotExist {
+		t.Errorf("Logf index 1 got %v", err)
+	}
+	want := "open file does not exist\n" +
+		"open file does not exist\n" +
+		"invalid argument\n" +
+		"open: file does not exist\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestLogIfErr(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	if err := ShortFile.LogIfErr(os.ErrInvalid, "close", "config"); err !=
+		os.ErrInvalid {
+		t.Errorf("got %v, want %v", err, os.ErrInvalid)
+	}
+	_, _, line, _ := runtime.Caller(0)
+	if err := ShortFile.LogIfErr(nil, "close"); err != nil {
+		t.Errorf("nil got %v", err)
+	}
+	if err := Plain.LogIfErr(os.ErrNotExist); err != os.ErrNotExist {
+		t.Errorf("no context got %v", err)
+	}
+	want := fmt.Sprint("errarg_test.go:", line-4,
+		": close config invalid argument\n",
+		"file does not exist\n")
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
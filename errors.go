@@ -0,0 +1,92 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync/atomic"
+)
+
+var (
+	expandErrors   int32
+	showErrorStack int32
+)
+
+// Atomic change of whether an error args[0] is printed as its whole
+// errors.Unwrap chain joined by ": ". This adds the messages of wrapped
+// errors that their wrapper omits; the returned error is still args[0].
+func SetExpandErrors(enable bool) {
+	var v int32
+	if enable {
+		v = 1
+	}
+	atomic.StoreInt32(&expandErrors, v)
+}
+
+// Return args with the error arg replaced by its expanded chain, if enabled.
+func expandargs(err error, args []interface{}) []interface{} {
+	if err == nil || atomic.LoadInt32(&expandErrors) == 0 ||
+		!reflect.TypeOf(err).Comparable() {
+		return args
+	}
+	c := make([]interface{}, len(args))
+	copy(c, args)
+	for i, arg := range c {
+		if arg == err {
+			c[i] = expand(err)
+			break
+		}
+	}
+	return c
+}
+
+// Return each message of the error chain, less the suffix of its wrapped
+// error, joined by ": ".
+func expand(err error) string {
+	var parts []string
+	for ; err != nil; err = errors.Unwrap(err) {
+		s := err.Error()
+		if next := errors.Unwrap(err); next != nil {
+			s = strings.TrimSuffix(s, ": "+next.Error())
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, ": ")
+}
+
+// An error that carries the program counters of its origin.
+type stackTracer interface {
+	StackTrace() []uintptr
+}
+
+// Atomic change of whether a line logging an error, i.e. args[0], that has
+// a StackTrace() []uintptr method, or wraps one that does, is followed by
+// that stack, formatted like Stack.
+func SetShowErrorStack(enable bool) {
+	var v int32
+	if enable {
+		v = 1
+	}
+	atomic.StoreInt32(&showErrorStack, v)
+}
+
+// Return "\n" and the formatted stack carried by err, if enabled;
+// otherwise, an empty string.
+func errstack(err error) string {
+	if err == nil || atomic.LoadInt32(&showErrorStack) == 0 {
+		return ""
+	}
+	var st stackTracer
+	if !errors.As(err, &st) {
+		return ""
+	}
+	s := stackof(st.StackTrace())
+	if len(s) == 0 {
+		return ""
+	}
+	return "\n" + s
+}
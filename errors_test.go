@@ -0,0 +1,80 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"runtime"
+	"testing"
+)
+
+// An error wrapper that omits the wrapped message.
+type opaqueError struct {
+	msg string
+	err error
+}
+
+func (e *opaqueError) Error() string { return e.msg }
+func (e *opaqueError) Unwrap() error { return e.err }
+
+func TestExpandErrors(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	defer SetExpandErrors(false)
+	err := fmt.Errorf("load: %w",
+		&opaqueError{"parse failed", os.ErrInvalid})
+	Plain.Log(err)
+	SetExpandErrors(true)
+	if got := Plain.Log(err); got != err {
+		t.Errorf("returned %v, want top-level error", got)
+	}
+	Plain.Log(fmt.Errorf("open: %w", os.ErrNotExist))
+	want := "load: parse failed\n" +
+		"load: parse failed: invalid argument\n" +
+		"open: file does not exist\n"
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// An error that carries the stack of its origin.
+type stackError struct{ pcs []uintptr }
+
+// Return the error and the line of its origin.
+func newStackError() (*stackError, int) {
+	pcs := make([]uintptr, 1)
+	n := runtime.Callers(1, pcs)
+	_, _, line, _ := runtime.Caller(0)
+	return &stackError{pcs[:n]}, line - 1
+}
+
+func (*stackError) Error() string           { return "stack error" }
+func (e *stackError) StackTrace() []uintptr { return e.pcs }
+
+func TestShowErrorStack(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	defer SetShowErrorStack(false)
+	err, line := newStackError()
+	_, file, _, _ := runtime.Caller(0)
+	Plain.Log(err)
+	Plain.Log(os.ErrInvalid)
+	SetShowErrorStack(true)
+	Plain.Log(os.ErrInvalid)
+	Plain.Log(fmt.Errorf("wrapped: %w", err))
+	want := fmt.Sprint("stack error\n",
+		"invalid argument\n",
+		"invalid argument\n",
+		"wrapped: stack error\n",
+		"github.com/platinasystems/dbg.newStackError()\n",
+		"\t", file, ":", line, "\n")
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg_test
+
+import (
+	"os"
+
+	"github.com/platinasystems/dbg"
+)
+
+func ExampleStyle_Log() {
+	dbg.ResetWriter() // os.Stdout
+	defer dbg.StubFileLine("/src/example.com/cmd/main.go", 22)()
+	dbg.FileLine.Log("hello", "world")
+	dbg.Plain.Logf("%d %s", 2, "args")
+	err := dbg.ShortFile.Log(os.ErrInvalid, "opening config")
+	dbg.Plain.Log(err == os.ErrInvalid)
+	// Output:
+	// main.go:22: hello world
+	// 2 args
+	// main.go:22: invalid argument opening config
+	// true
+}
+
+func ExampleStyle_WithPrefix() {
+	dbg.ResetWriter()
+	defer dbg.StubFileLine("/src/example.com/net/link.go", 38)()
+	net := dbg.FileLine.WithPrefix("[net] ").With("if", "eth0")
+	net.Log("up")
+	// Output:
+	// link.go:38: [net] up if=eth0
+}
@@ -0,0 +1,21 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import "path/filepath"
+
+// Report callers at the given file and line, relative to the file's
+// directory, until the returned func restores the caller lookup and path
+// base. This lets examples show FileLine output regardless of the working
+// directory, GOPATH, or -trimpath.
+func StubFileLine(file string, line int) func() {
+	base, _ := pathBase.Load().(string)
+	SetPathBase(filepath.Dir(file))
+	restore := stubCaller(file, line)
+	return func() {
+		restore()
+		SetPathBase(base)
+	}
+}
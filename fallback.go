@@ -0,0 +1,44 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+var (
+	fallback       int32
+	fallbackWarned int32
+)
+
+// Atomic change of whether a line that fails to write is written again to
+// the ErrorWriter, os.Stderr by default, after a one-time warning of the
+// error. Log and the like still return args[0], whereas Output and Fprintf
+// return the original write error.
+func SetStderrFallback(enable bool) {
+	var v int32
+	if enable {
+		v = 1
+	}
+	atomic.StoreInt32(&fallbackWarned, 0)
+	atomic.StoreInt32(&fallback, v)
+}
+
+// Write the line to the ErrorWriter if enabled and w isn't that already.
+func fallbackWrite(w io.Writer, line string, werr error) {
+	if atomic.LoadInt32(&fallback) == 0 {
+		return
+	}
+	ew := CurrentErrorWriter()
+	if containsWriter([]io.Writer{ew}, w) {
+		return
+	}
+	if atomic.CompareAndSwapInt32(&fallbackWarned, 0, 1) {
+		fmt.Fprintf(ew, "dbg: %v; writing to stderr instead\n", werr)
+	}
+	io.WriteString(ew, line)
+}
@@ -0,0 +1,34 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestStderrFallback(t *testing.T) {
+	defer Writer(CurrentWriter())
+	defer ErrorWriter(CurrentErrorWriter())
+	errs := new(bytes.Buffer)
+	ErrorWriter(errs)
+	Writer(errWriter{os.ErrClosed})
+	Plain.Log("lost")
+	if errs.Len() != 0 {
+		t.Fatalf("disabled got %q", errs)
+	}
+	SetStderrFallback(true)
+	defer SetStderrFallback(false)
+	Plain.Log("one")
+	if err := Plain.Output(1, "two"); err != os.ErrClosed {
+		t.Errorf("Output got %v, want %v", err, os.ErrClosed)
+	}
+	want := "dbg: file already closed; writing to stderr instead\n" +
+		"one\ntwo\n"
+	if got := errs.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
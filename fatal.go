@@ -0,0 +1,40 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import "os"
+
+// Tests may replace this to intercept Fatal.
+var exit = os.Exit
+
+// Error level Log then exit(1); even NoOp exits.
+func (style Style) Fatal(args ...interface{}) {
+	style.log(Error, 0, "", nil, args...)
+	exit(1)
+}
+
+// Error level Logf then exit(1); even NoOp exits.
+func (style Style) Fatalf(format string, args ...interface{}) {
+	style.log(Error, 0, format, nil, args...)
+	exit(1)
+}
+
+// Error level Log then panic with args[0], if it's an error, or the
+// message; even NoOp panics.
+func (style Style) Panic(args ...interface{}) {
+	if err := style.log(Error, 0, "", nil, args...); err != nil {
+		panic(err)
+	}
+	panic(message("", args))
+}
+
+// Error level Logf then panic with args[0], if it's an error, or the
+// formatted message; even NoOp panics.
+func (style Style) Panicf(format string, args ...interface{}) {
+	if err := style.log(Error, 0, format, nil, args...); err != nil {
+		panic(err)
+	}
+	panic(message(format, args))
+}
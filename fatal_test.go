@@ -0,0 +1,63 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestFatal(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	var codes []int
+	defer func(f func(int)) { exit = f }(exit)
+	exit = func(code int) { codes = append(codes, code) }
+	_, _, line, _ := runtime.Caller(0)
+	FileLine.Fatal("fatal")
+	FileLine.Fatalf("%s", "fatalf")
+	NoOp.Fatal("noop")
+	want := fmt.Sprint("fatal_test.go:", line+1, ": fatal\n",
+		"fatal_test.go:", line+2, ": fatalf\n")
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+	if fmt.Sprint(codes) != "[1 1 1]" {
+		t.Error("exit codes:", codes)
+	}
+}
+
+func TestPanic(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	recovered := func(f func()) (v interface{}) {
+		defer func() { v = recover() }()
+		f()
+		return
+	}
+	_, _, line, _ := runtime.Caller(0)
+	v := recovered(func() { FileLine.Panic("panic", 1) })
+	if v != "panic 1" {
+		t.Errorf("Panic recovered %#v", v)
+	}
+	v = recovered(func() { FileLine.Panicf("%v: %d", os.ErrInvalid, 2) })
+	if v != os.ErrInvalid {
+		t.Errorf("Panicf recovered %#v", v)
+	}
+	v = recovered(func() { NoOp.Panicf("%s", "noop") })
+	if v != "noop" {
+		t.Errorf("NoOp recovered %#v", v)
+	}
+	want := fmt.Sprint("fatal_test.go:", line+1, ": panic 1\n",
+		"fatal_test.go:", line+5, ": invalid argument: 2\n")
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import "sync/atomic"
+
+// A filter returns whether to write the formatted message of the style from
+// the given caller file and line.
+type filterFunc func(style Style, file string, line int, msg string) bool
+
+// Boxed so that atomic.Value always stores the same concrete type.
+type filterBox struct{ f filterFunc }
+
+var filter atomic.Value
+
+// Atomic change of the func that decides whether each formatted message is
+// written; a false return drops the line, though Log and Logf still return
+// args[0] if it's an error. The file is the caller's absolute path. A nil f
+// clears the filter.
+func SetFilter(f func(style Style, file string, line int, msg string) bool) {
+	filter.Store(filterBox{f})
+}
+
+// Return the filter, or nil if clear.
+func currentFilter() filterFunc {
+	b, _ := filter.Load().(filterBox)
+	return b.f
+}
@@ -0,0 +1,45 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	defer SetFilter(nil)
+	var files []string
+	SetFilter(func(style Style, file string, line int, msg string) bool {
+		files = append(files, filepath.Base(file))
+		return !strings.Contains(msg, "noisy")
+	})
+	Plain.Log("keep")
+	Plain.Log("noisy heartbeat")
+	if err := Plain.Log(os.ErrInvalid, "noisy"); err != os.ErrInvalid {
+		t.Error("filtered didn't return error")
+	}
+	Plain.Logf("%s", "keep too")
+	SetFilter(nil)
+	Plain.Log("noisy but unfiltered")
+	want := "keep\nkeep too\nnoisy but unfiltered\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	for _, file := range files {
+		if file != "filter_test.go" {
+			t.Errorf("filter file %q", file)
+		}
+	}
+	if len(files) != 4 {
+		t.Errorf("filtered %d lines, want 4", len(files))
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import "runtime"
+
+// Like Logf but, like fmt.Fprintf, return the number of bytes written,
+// including the style prefix and newline. The error is that of the write
+// or, if none, args[0] if it's an error. Nothing is written, so the count
+// is 0, for a NoOp style, a suppressed level, or a filtered line.
+func (style Style) Fprintf(format string, args ...interface{}) (int, error) {
+	if len(args) == 0 || args[0] == nil {
+		return 0, nil
+	}
+	err := errarg(args)
+	if !style.Enabled() {
+		return 0, err
+	}
+	l := Logger{style: style}
+	var frame runtime.Frame
+	if l.framed() {
+		frame = caller(1)
+	}
+	n, werr := l.writen(Info, frame, format, err, args)
+	if werr != nil {
+		return n, werr
+	}
+	return n, err
+}
@@ -0,0 +1,33 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestFprintf(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	n, err := FileLine.Fprintf("%s %d", "count", 1)
+	if err != nil || n != buf.Len() {
+		t.Errorf("got %d, %v; want %d, nil", n, err, buf.Len())
+	}
+	buf.Reset()
+	n, err = Plain.Fprintf("%v", os.ErrInvalid)
+	if err != os.ErrInvalid || n != len("invalid argument\n") {
+		t.Errorf("error arg got %d, %v", n, err)
+	}
+	if n, err = NoOp.Fprintf("%s", "quiet"); n != 0 || err != nil {
+		t.Errorf("NoOp got %d, %v", n, err)
+	}
+	Writer(errWriter{os.ErrClosed})
+	if n, err = Plain.Fprintf("%v", os.ErrInvalid); err != os.ErrClosed {
+		t.Errorf("write error got %d, %v", n, err)
+	}
+}
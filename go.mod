@@ -1 +1,3 @@
 module github.com/platinasystems/dbg
+
+go 1.21
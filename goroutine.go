@@ -0,0 +1,51 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+)
+
+var showGoroutine int32
+
+// Atomic change of whether each line begins with the logging goroutine's
+// ID, e.g. "[G18] ". This is off by default since Go doesn't otherwise
+// expose the ID, so each line costs a runtime.Stack call to parse it.
+func SetShowGoroutine(enable bool) {
+	var v int32
+	if enable {
+		v = 1
+	}
+	atomic.StoreInt32(&showGoroutine, v)
+}
+
+func goroutinePrefix() string {
+	if atomic.LoadInt32(&showGoroutine) == 0 {
+		return ""
+	}
+	return fmt.Sprint("[G", goid(), "] ")
+}
+
+// Parse the goroutine ID from the first line of its stack,
+//
+//	goroutine 18 [running]:
+func goid() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	const prefix = "goroutine "
+	if len(b) < len(prefix) {
+		return 0
+	}
+	b = b[len(prefix):]
+	n := 0
+	for n < len(b) && b[n] >= '0' && b[n] <= '9' {
+		n++
+	}
+	id, _ := strconv.ParseUint(string(b[:n]), 10, 64)
+	return id
+}
@@ -0,0 +1,64 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestShowGoroutine(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	SetShowGoroutine(true)
+	defer SetShowGoroutine(false)
+	for i := 0; i < 2; i++ {
+		done := make(chan struct{})
+		go func() {
+			Plain.Log("hello")
+			close(done)
+		}()
+		<-done
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %q", buf)
+	}
+	var ids [2]uint64
+	for i, line := range lines {
+		if _, err := fmt.Sscanf(line, "[G%d] hello", &ids[i]); err != nil {
+			t.Fatalf("%q: %v", line, err)
+		}
+	}
+	if ids[0] == ids[1] || ids[0] == 0 {
+		t.Fatal("indistinguishable goroutines", ids)
+	}
+	SetShowGoroutine(false)
+	buf.Reset()
+	Plain.Log("hello")
+	if got := buf.String(); got != "hello\n" {
+		t.Fatalf("disabled got %q", got)
+	}
+}
+
+func TestShowGoroutineJSON(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	SetShowGoroutine(true)
+	defer SetShowGoroutine(false)
+	JSON.Log("hello")
+	var v jsonLine
+	if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Goroutine != goid() {
+		t.Fatalf("got %d, want %d", v.Goroutine, goid())
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"sync"
+)
+
+type gzipFileWriter struct {
+	mu sync.Mutex
+	f  *os.File
+	zw *gzip.Writer
+}
+
+// Return a writer that gzip compresses to the file at path, creating it or
+// appending another gzip member, e.g.
+//
+//	w, err := dbg.NewGzipFileWriter("debug.log.gz")
+//	if err != nil {
+//		return err
+//	}
+//	defer w.Close()
+//	dbg.Writer(w)
+//
+// Close flushes the compressed stream then closes the file.
+func NewGzipFileWriter(path string) (io.WriteCloser, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &gzipFileWriter{f: f, zw: gzip.NewWriter(f)}, nil
+}
+
+func (w *gzipFileWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.zw == nil {
+		return 0, os.ErrClosed
+	}
+	return w.zw.Write(b)
+}
+
+func (w *gzipFileWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.zw == nil {
+		return os.ErrClosed
+	}
+	err := w.zw.Close()
+	if ferr := w.f.Close(); err == nil {
+		err = ferr
+	}
+	w.zw = nil
+	return err
+}
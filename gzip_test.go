@@ -0,0 +1,50 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGzipFileWriter(t *testing.T) {
+	defer Writer(CurrentWriter())
+	path := filepath.Join(t.TempDir(), "debug.log.gz")
+	for _, s := range []string{"one", "two"} {
+		w, err := NewGzipFileWriter(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		Writer(w)
+		Plain.Log(s)
+		Plain.Logf("%s again", s)
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte("late")); err != os.ErrClosed {
+			t.Errorf("write after close got %v", err)
+		}
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "one\none again\ntwo\ntwo again\n"
+	if got := string(b); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
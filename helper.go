@@ -0,0 +1,42 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var helpers struct {
+	sync.Mutex
+	// copy-on-write set
+	set atomic.Value
+}
+
+// Register the qualified names of wrapper functions that the caller lookup
+// walks past to report their caller instead, e.g.
+//
+//	func debugf(format string, args ...interface{}) {
+//		dbg.Func.Logf(format, args...)
+//	}
+//
+//	func init() {
+//		dbg.RegisterHelper("example.com/pkg.debugf")
+//	}
+func RegisterHelper(names ...string) {
+	helpers.Lock()
+	defer helpers.Unlock()
+	set := loadpkgset(&helpers.set)
+	for _, name := range names {
+		set = set.with(name)
+	}
+	helpers.set.Store(set)
+}
+
+// Return whether the qualified function name is a registered helper.
+func helper(name string) bool {
+	set := loadpkgset(&helpers.set)
+	return len(set) > 0 && set[name]
+}
@@ -0,0 +1,34 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"testing"
+)
+
+func debugfHelper(style Style, format string, args ...interface{}) {
+	style.Logf(format, args...)
+}
+
+func TestRegisterHelper(t *testing.T) {
+	defer Writer(CurrentWriter())
+	defer func(v interface{}) { helpers.set.Store(v) }(loadpkgset(&helpers.set))
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	debugfHelper(Func, "%s", "wrapper")
+	RegisterHelper("github.com/platinasystems/dbg.debugfHelper")
+	debugfHelper(Func, "%s", "caller")
+	_, _, line, _ := runtime.Caller(0)
+	debugfHelper(FileLine, "%s", "line")
+	if got, want := buf.String(), fmt.Sprint(
+		"github.com/platinasystems/dbg.debugfHelper() wrapper\n",
+		"github.com/platinasystems/dbg.TestRegisterHelper() caller\n",
+		"helper_test.go:", line+1, ": line\n"); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+var indent int32
+
+// Atomic increment of the indent level; each level inserts two spaces between
+// the style prefix and the message, e.g.
+//
+//	dbg.Indent()
+//	defer dbg.Dedent()
+//
+// The level is global rather than per goroutine, so concurrent nested traces
+// interleave their indentation.
+func Indent() {
+	atomic.AddInt32(&indent, 1)
+}
+
+// Atomic decrement of the indent level, stopping at zero.
+func Dedent() {
+	for {
+		n := atomic.LoadInt32(&indent)
+		if n <= 0 || atomic.CompareAndSwapInt32(&indent, n, n-1) {
+			return
+		}
+	}
+}
+
+func indentation() string {
+	n := atomic.LoadInt32(&indent)
+	if n <= 0 {
+		return ""
+	}
+	return strings.Repeat("  ", int(n))
+}
@@ -0,0 +1,34 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestIndent(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	Pkg.Log("zero")
+	Indent()
+	Pkg.Log("one")
+	Indent()
+	Pkg.Log("two")
+	Dedent()
+	Pkg.Log("one")
+	Dedent()
+	Dedent()
+	Pkg.Log("zero")
+	want := "github.com/platinasystems/dbg: zero\n" +
+		"github.com/platinasystems/dbg:   one\n" +
+		"github.com/platinasystems/dbg:     two\n" +
+		"github.com/platinasystems/dbg:   one\n" +
+		"github.com/platinasystems/dbg: zero\n"
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
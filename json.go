@@ -0,0 +1,79 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"sync/atomic"
+)
+
+var jsonTimeLevel int32
+
+// Atomic change of whether JSON style objects begin with "time" and "level"
+// fields, e.g. for a NewJSONFileWriter behind a MultiWriter. The default,
+// false, omits these.
+func SetJSONTimeLevel(enable bool) {
+	var v int32
+	if enable {
+		v = 1
+	}
+	atomic.StoreInt32(&jsonTimeLevel, v)
+}
+
+type jsonLine struct {
+	Time      string `json:"time,omitempty"`
+	Level     string `json:"level,omitempty"`
+	Seq       uint64 `json:"seq,omitempty"`
+	PID       int    `json:"pid,omitempty"`
+	Host      string `json:"host,omitempty"`
+	Elapsed   string `json:"elapsed,omitempty"`
+	Goroutine uint64 `json:"goroutine,omitempty"`
+	File      string `json:"file"`
+	Line      int    `json:"line"`
+	Func      string `json:"func"`
+	Msg       string `json:"msg"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Return the JSON style line, less newline, of the given caller frame.
+func jsonline(level Level, frame runtime.Frame, msg string, err error,
+	seq uint64) string {
+	v := jsonLine{
+		Seq:  seq,
+		Func: frame.Function,
+		Line: frame.Line,
+		Msg:  msg,
+	}
+	if atomic.LoadInt32(&jsonTimeLevel) != 0 {
+		v.Time = timestamp()
+		v.Level = level.String()
+	}
+	if len(frame.File) > 0 {
+		v.File = relfile(frame.File)
+	}
+	if err != nil {
+		v.Error = err.Error()
+	}
+	if atomic.LoadInt32(&showPID) != 0 {
+		v.PID = os.Getpid()
+	}
+	if atomic.LoadInt32(&showHost) != 0 {
+		v.Host = host()
+	}
+	if atomic.LoadInt32(&showElapsed) != 0 {
+		v.Elapsed = elapsed()
+	}
+	if atomic.LoadInt32(&showGoroutine) != 0 {
+		v.Goroutine = goid()
+	}
+	b, jerr := json.Marshal(&v)
+	if jerr != nil {
+		return fmt.Sprint(jerr)
+	}
+	return string(b)
+}
@@ -0,0 +1,51 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	_, _, line, _ := runtime.Caller(0)
+	ferr := JSON.Logf("%s %d", "formatted", 1)
+	perr := JSON.Log(os.ErrInvalid, "printed")
+	if ferr != nil {
+		t.Fatal("not nil")
+	}
+	if perr != os.ErrInvalid {
+		t.Fatal("not invalid")
+	}
+	dec := json.NewDecoder(buf)
+	for _, want := range []jsonLine{
+		{
+			File: "json_test.go",
+			Line: line + 1,
+			Func: "github.com/platinasystems/dbg.TestJSON",
+			Msg:  "formatted 1",
+		},
+		{
+			File:  "json_test.go",
+			Line:  line + 2,
+			Func:  "github.com/platinasystems/dbg.TestJSON",
+			Msg:   "invalid argument printed",
+			Error: "invalid argument",
+		},
+	} {
+		var got jsonLine
+		if err := dec.Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("\ngot:  %+v\nwant: %+v", got, want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// The number of path.N backups kept by a JSON file writer.
+const jsonFileBackups = 10
+
+type jsonFileWriter struct{ *rotatingWriter }
+
+// A record of a line that isn't a JSON style object.
+type jsonFileRecord struct {
+	Time  string `json:"time"`
+	Level string `json:"level"`
+	Msg   string `json:"msg"`
+}
+
+// Return a writer for the JSON style that writes one object per line to the
+// file at path, rotated like NewRotatingWriter with up to 10 backups. Every
+// object has its time and level; these are Info for lines written through
+// another writer, e.g. MultiWriter, unless set with SetJSONTimeLevel. Lines
+// that aren't JSON style objects, e.g. of another style, are written as
+// objects of time, level, and msg, e.g.
+//
+//	w, err := dbg.NewJSONFileWriter("debug.ndjson", 1<<20)
+//	if err != nil {
+//		return err
+//	}
+//	dbg.RegisterCloser(w)
+//	dbg.WriterForStyle(dbg.JSON, w)
+func NewJSONFileWriter(path string, maxBytes int64) (io.WriteCloser, error) {
+	w, err := NewRotatingWriter(path, maxBytes, jsonFileBackups)
+	if err != nil {
+		return nil, err
+	}
+	return jsonFileWriter{w.(*rotatingWriter)}, nil
+}
+
+func (w jsonFileWriter) Write(b []byte) (int, error) {
+	if _, err := w.WriteLevel(Info.String(), string(b)); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (w jsonFileWriter) WriteLevel(level, s string) (int, error) {
+	var buf bytes.Buffer
+	for _, line := range strings.SplitAfter(s, "\n") {
+		line = strings.TrimRight(line, "\r\n")
+		if len(line) == 0 {
+			continue
+		}
+		var v interface{}
+		var obj jsonLine
+		if json.Unmarshal([]byte(line), &obj) == nil {
+			if len(obj.Time) == 0 {
+				obj.Time = timestamp()
+			}
+			if len(obj.Level) == 0 {
+				obj.Level = level
+			}
+			v = &obj
+		} else {
+			v = &jsonFileRecord{
+				Time:  timestamp(),
+				Level: level,
+				Msg:   line,
+			}
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return 0, err
+		}
+		buf.Write(b)
+		buf.WriteByte('\n')
+	}
+	if _, err := w.rotatingWriter.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(s), nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONFileWriter(t *testing.T) {
+	defer fakeNow(time.Date(2018, 10, 23, 10, 4, 5, 6000, time.UTC))()
+	defer WriterForStyle(JSON, nil)
+	defer WriterForStyle(Plain, nil)
+	defer SetJSONTimeLevel(false)
+	path := filepath.Join(t.TempDir(), "debug.ndjson")
+	w, err := NewJSONFileWriter(path, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	WriterForStyle(JSON, w)
+	WriterForStyle(Plain, w)
+	JSON.Warnf("%s", "direct")
+	Plain.Warnf("%s", "plain")
+	WriterForStyle(JSON, MultiWriter(w))
+	WriterForStyle(Plain, MultiWriter(w))
+	JSON.Warnf("%s", "wrapped")
+	Plain.Warnf("%s", "wrapped plain")
+	SetJSONTimeLevel(true)
+	JSON.Errorf("%s", "wrapped with level")
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	names := []string{path}
+	for i := 1; ; i++ {
+		name := fmt.Sprint(path, ".", i)
+		if _, err := os.Stat(name); err != nil {
+			break
+		}
+		names = append([]string{name}, names...)
+	}
+	if len(names) < 2 {
+		t.Error("not rotated")
+	}
+	for _, name := range names {
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		f.Close()
+	}
+	const fn = "github.com/platinasystems/dbg.TestJSONFileWriter"
+	want := []struct {
+		msg, level string
+		framed     bool
+	}{
+		{"direct", "Warn", true},
+		{"plain", "Warn", false},
+		{"wrapped", "Info", true},
+		{"wrapped plain", "Info", false},
+		{"wrapped with level", "Error", true},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d objects, want %d", len(lines), len(want))
+	}
+	for i, line := range lines {
+		var v jsonLine
+		if err := json.Unmarshal([]byte(line), &v); err != nil {
+			t.Errorf("[%d] %v: %s", i, err, line)
+			continue
+		}
+		x := want[i]
+		if v.Msg != x.msg || v.Level != x.level || len(v.Time) == 0 {
+			t.Errorf("[%d] got %s", i, line)
+		}
+		if framed := v.File == "jsonfile_test.go" && v.Line > 0 &&
+			v.Func == fn; framed != x.framed {
+			t.Errorf("[%d] framed %t, got %s", i, framed, line)
+		}
+		if !x.framed && strings.Contains(line, `"file"`) {
+			t.Errorf("[%d] empty fields %s", i, line)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+// Like Log but print the args as alternating keys and values with logfmt
+// quoting, e.g.
+//
+//	dbg.Plain.KV("req", 123, "path", "/a b") // req=123 path="/a b"
+//
+// An odd, trailing key has the value MISSING_VALUE. Like Log, this returns
+// args[0] if it's an error.
+func (style Style) KV(keyvals ...interface{}) error {
+	if len(keyvals) == 0 || keyvals[0] == nil {
+		return nil
+	}
+	err := errarg(keyvals)
+	if !style.Enabled() {
+		return err
+	}
+	if len(keyvals)%2 != 0 {
+		keyvals = append(keyvals[:len(keyvals):len(keyvals)],
+			"MISSING_VALUE")
+	}
+	l := Logger{style: style}
+	l.output(Info, 1, "", []interface{}{logfmt(keyvals)[1:]})
+	return err
+}
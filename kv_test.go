@@ -0,0 +1,34 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestKV(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	Plain.KV("req", 123, "path", "/a b")
+	Plain.KV("user", "bob", "orphan")
+	if err := Plain.KV(os.ErrInvalid, "x"); err != os.ErrInvalid {
+		t.Error("didn't return error")
+	}
+	NoOp.KV("quiet", true)
+	ShortFile.KV("k", `say "hi"`)
+	_, _, line, _ := runtime.Caller(0)
+	want := fmt.Sprint("req=123 path=\"/a b\"\n",
+		"user=bob orphan=MISSING_VALUE\n",
+		"\"invalid argument\"=x\n",
+		"kv_test.go:", line-1, ": k=\"say \\\"hi\\\"\"\n")
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
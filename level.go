@@ -0,0 +1,88 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// Levels: Debug, Info, Warn, or Error.
+type Level int32
+
+const (
+	Debug Level = iota
+	Info
+	Warn
+	Error
+	nLevels
+)
+
+var minLevel int32
+
+// Atomic change of the minimum level logged; the default, Debug, logs all.
+// Log and Logf are Info level.
+func SetMinLevel(level Level) {
+	atomic.StoreInt32(&minLevel, int32(level))
+}
+
+// Return the minimum level logged.
+func MinLevel() Level {
+	return Level(atomic.LoadInt32(&minLevel))
+}
+
+// Return name of level.
+func (level Level) String() string {
+	if level < 0 || level >= nLevels {
+		return fmt.Sprint(int(level))
+	}
+	return []string{
+		"Debug",
+		"Info",
+		"Warn",
+		"Error",
+	}[level]
+}
+
+// Like Logf but dropped if Debug is below the minimum level.
+func (style Style) Debugf(format string, args ...interface{}) error {
+	return style.log(Debug, 0, format, nil, args...)
+}
+
+// Same as Logf.
+func (style Style) Infof(format string, args ...interface{}) error {
+	return style.log(Info, 0, format, nil, args...)
+}
+
+// Like Logf but dropped if Warn is below the minimum level.
+func (style Style) Warnf(format string, args ...interface{}) error {
+	return style.log(Warn, 0, format, nil, args...)
+}
+
+// Like Logf but dropped if Error is below the minimum level. Regardless of
+// style and level, Errorf returns the message as a new error that, like
+// fmt.Errorf, wraps any %w operand,
+//
+//	return dbg.Err.Errorf("bad %d", n)
+func (style Style) Errorf(format string, args ...interface{}) error {
+	err := fmt.Errorf(format, args...)
+	style.log(Error, 0, "", nil, err)
+	return err
+}
+
+// Return whether Log and Logf may print. Use this to guard costly
+// arguments,
+//
+//	if dbg.Err.Enabled() {
+//		dbg.Err.Logf("%v", expensive())
+//	}
+func (style Style) Enabled() bool {
+	return style.EnabledLevel(Info)
+}
+
+// Return whether the style may print messages of the given level.
+func (style Style) EnabledLevel(level Level) bool {
+	return style != NoOp && enabled() && level >= MinLevel()
+}
@@ -0,0 +1,109 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestMinLevel(t *testing.T) {
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	defer SetMinLevel(MinLevel())
+	SetMinLevel(Warn)
+	Plain.Debugf("%s", "suppressed")
+	Plain.Infof("%s", "suppressed")
+	Plain.Logf("%s", "suppressed")
+	Plain.Warnf("%s", "warning")
+	Plain.Errorf("%s", "error")
+	if err := Plain.Debugf("%v", os.ErrInvalid); err != os.ErrInvalid {
+		t.Fatal("suppressed Debugf didn't return error")
+	}
+	want := "warning\nerror\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestLevelString(t *testing.T) {
+	for level, want := range map[Level]string{
+		Debug:   "Debug",
+		Error:   "Error",
+		nLevels: "4",
+		-1:      "-1",
+	} {
+		if got := level.String(); got != want {
+			t.Errorf("Level(%d).String() = %q, want %q",
+				int(level), got, want)
+		}
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	defer SetMinLevel(MinLevel())
+	if NoOp.Enabled() {
+		t.Error("NoOp enabled")
+	}
+	if !Plain.Enabled() || !Plain.EnabledLevel(Debug) {
+		t.Error("Plain disabled")
+	}
+	SetMinLevel(Warn)
+	if Plain.Enabled() || Plain.EnabledLevel(Debug) {
+		t.Error("Plain enabled below Warn")
+	}
+	if !Plain.EnabledLevel(Error) {
+		t.Error("Plain Error disabled")
+	}
+	if n := testing.AllocsPerRun(100, guardedLog); n != 0 {
+		t.Error("disabled guard allocated", n)
+	}
+}
+
+func guardedLog() {
+	if NoOp.Enabled() {
+		NoOp.Logf("%d %s", 1, "boxed")
+	}
+}
+
+func BenchmarkEnabledNoOp(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		guardedLog()
+	}
+}
+
+func BenchmarkUnguardedNoOp(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		NoOp.Logf("%d %s", i, "boxed")
+	}
+}
+
+func TestErrorf(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	err := Plain.Errorf("bad %d: %w", 3, os.ErrInvalid)
+	if got, want := err.Error(), "bad 3: invalid argument"; got != want {
+		t.Errorf("error got %q, want %q", got, want)
+	}
+	if !errors.Is(err, os.ErrInvalid) {
+		t.Error("doesn't wrap os.ErrInvalid")
+	}
+	if got, want := buf.String(), "bad 3: invalid argument\n"; got != want {
+		t.Errorf("logged %q, want %q", got, want)
+	}
+	buf.Reset()
+	if err := NoOp.Errorf("quiet %d", 4); err == nil ||
+		err.Error() != "quiet 4" {
+		t.Errorf("NoOp got %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("NoOp logged %q", buf)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+var (
+	every struct {
+		sync.Mutex
+		last map[uintptr]time.Time
+	}
+	once sync.Map
+)
+
+// Like Logf but drop all but one line per duration from the same call site.
+// Dropped calls still return args[0] if it's an error.
+func (style Style) LogfEvery(d time.Duration, format string,
+	args ...interface{}) error {
+	if !style.Enabled() {
+		return errarg(args)
+	}
+	pc, _, _, _ := runtime.Caller(1)
+	now := nowFunc()
+	every.Lock()
+	last, found := every.last[pc]
+	emit := !found || now.Sub(last) >= d
+	if emit {
+		if every.last == nil {
+			every.last = make(map[uintptr]time.Time)
+		}
+		every.last[pc] = now
+	}
+	every.Unlock()
+	if !emit {
+		return errarg(args)
+	}
+	return style.log(Info, 0, format, nil, args...)
+}
+
+// Like Log but only print the first line from the call site. Later calls
+// still return args[0] if it's an error.
+func (style Style) LogOnce(args ...interface{}) error {
+	if !style.Enabled() {
+		return errarg(args)
+	}
+	pc, _, _, _ := runtime.Caller(1)
+	if _, loaded := once.LoadOrStore(pc, struct{}{}); loaded {
+		return errarg(args)
+	}
+	return style.log(Info, 0, "", nil, args...)
+}
@@ -0,0 +1,96 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLogfEvery(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	every.Lock()
+	every.last = nil
+	every.Unlock()
+	now := time.Date(2018, 10, 23, 0, 0, 0, 0, time.UTC)
+	defer func(f func() time.Time) { nowFunc = f }(nowFunc)
+	nowFunc = func() time.Time { return now }
+	logf := func(i int) error {
+		return Plain.LogfEvery(time.Second, "%v %d", os.ErrInvalid, i)
+	}
+	for i, dt := range []time.Duration{
+		0,
+		time.Millisecond,
+		time.Second,
+		time.Millisecond,
+	} {
+		now = now.Add(dt)
+		if err := logf(i); err != os.ErrInvalid {
+			t.Fatal(i, "didn't return error")
+		}
+	}
+	want := "invalid argument 0\ninvalid argument 2\n"
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestLogOnce(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	once.Range(func(k, _ interface{}) bool {
+		once.Delete(k)
+		return true
+	})
+	for i := 0; i < 100; i++ {
+		if err := Plain.LogOnce(os.ErrInvalid, i); err != os.ErrInvalid {
+			t.Fatal(i, "didn't return error")
+		}
+	}
+	Plain.LogOnce("another site")
+	want := "invalid argument 0\nanother site\n"
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestLimitDisabled(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	defer SetEnabled(true)
+	defer SetMinLevel(MinLevel())
+	for _, disable := range []func(){
+		func() { SetEnabled(false) },
+		func() { SetMinLevel(Warn) },
+	} {
+		every.Lock()
+		every.last = nil
+		every.Unlock()
+		once.Range(func(k, _ interface{}) bool {
+			once.Delete(k)
+			return true
+		})
+		for i := 0; i < 2; i++ {
+			if i == 0 {
+				disable()
+			} else {
+				SetEnabled(true)
+				SetMinLevel(Debug)
+			}
+			Plain.LogOnce("once", i)
+			Plain.LogfEvery(time.Hour, "every %d", i)
+		}
+	}
+	want := "once 1\nevery 1\nonce 1\nevery 1\n"
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
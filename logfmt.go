@@ -0,0 +1,42 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"os"
+	"runtime"
+	"sync/atomic"
+)
+
+// Return the Logfmt style line, less newline, of the given caller frame.
+func logfmtline(frame runtime.Frame, msg string, err error,
+	seq uint64) string {
+	var keyvals []interface{}
+	if seq != 0 {
+		keyvals = append(keyvals, "seq", seq)
+	}
+	if atomic.LoadInt32(&showPID) != 0 {
+		keyvals = append(keyvals, "pid", os.Getpid())
+	}
+	if atomic.LoadInt32(&showHost) != 0 {
+		keyvals = append(keyvals, "host", host())
+	}
+	if atomic.LoadInt32(&showElapsed) != 0 {
+		keyvals = append(keyvals, "elapsed", elapsed())
+	}
+	if atomic.LoadInt32(&showGoroutine) != 0 {
+		keyvals = append(keyvals, "goroutine", goid())
+	}
+	var file string
+	if len(frame.File) > 0 {
+		file = relfile(frame.File)
+	}
+	keyvals = append(keyvals, "file", file, "line", frame.Line,
+		"func", frame.Function, "msg", msg)
+	if err != nil {
+		keyvals = append(keyvals, "error", err.Error())
+	}
+	return logfmt(keyvals)[1:]
+}
@@ -0,0 +1,82 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Parse a logfmt line into its key/value pairs.
+func parseLogfmt(t *testing.T, line string) map[string]string {
+	m := make(map[string]string)
+	for len(line) > 0 {
+		i := strings.IndexByte(line, '=')
+		if i < 0 {
+			t.Fatalf("missing = in %q", line)
+		}
+		k := line[:i]
+		line = line[i+1:]
+		var v string
+		if strings.HasPrefix(line, `"`) {
+			q, err := strconv.QuotedPrefix(line)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v, err = strconv.Unquote(q); err != nil {
+				t.Fatal(err)
+			}
+			line = line[len(q):]
+		} else if i = strings.IndexByte(line, ' '); i < 0 {
+			v, line = line, ""
+		} else {
+			v, line = line[:i], line[i:]
+		}
+		m[k] = v
+		line = strings.TrimPrefix(line, " ")
+	}
+	return m
+}
+
+func TestLogfmt(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	_, _, line, _ := runtime.Caller(0)
+	Logfmt.Log("plain")
+	Logfmt.Log(errors.New(`bad "quote"`), "a=b")
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %q", buf)
+	}
+	const fn = "github.com/platinasystems/dbg.TestLogfmt"
+	for i, want := range []map[string]string{
+		{
+			"file": "logfmt_test.go",
+			"line": strconv.Itoa(line + 1),
+			"func": fn,
+			"msg":  "plain",
+		},
+		{
+			"file":  "logfmt_test.go",
+			"line":  strconv.Itoa(line + 2),
+			"func":  fn,
+			"msg":   `bad "quote" a=b`,
+			"error": `bad "quote"`,
+		},
+	} {
+		if got := parseLogfmt(t, lines[i]); !reflect.DeepEqual(got, want) {
+			t.Errorf("line %d got %q, want %q", i, got, want)
+		}
+	}
+	if !strings.Contains(lines[1], `msg="bad \"quote\" a=b"`) {
+		t.Errorf("unquoted msg %q", lines[1])
+	}
+}
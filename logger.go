@@ -0,0 +1,243 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+// A Logger is an independent debug channel with its own style, writer,
+// message prefix, key/value fields, and caller skip.
+type Logger struct {
+	style  Style
+	w      io.Writer
+	prefix string
+	fields string
+	skip   int
+	// without newline
+	partial bool
+	// without side effects on seq and delta, e.g. for Sprint
+	dry bool
+}
+
+// Return a Logger of the given style that prints to w or, if nil, including
+// a typed nil pointer, the style's writer.
+func NewLogger(style Style, w io.Writer) *Logger {
+	return &Logger{style: style, w: box(w).Writer}
+}
+
+// Return a Logger of the style, with its writer, and the given message
+// prefix, e.g.
+//
+//	var Net = dbg.FileLine.WithPrefix("[net] ")
+//	Net.Log("up") // net.go:22: [net] up
+func (style Style) WithPrefix(p string) *Logger {
+	return &Logger{style: style, prefix: p}
+}
+
+// Like Style.Log but with the logger's writer, prefix, and skip.
+func (l *Logger) Log(args ...interface{}) error {
+	return l.output(Info, 1+l.skip, "", args)
+}
+
+// Like Style.Logf but with the logger's writer, prefix, and skip.
+func (l *Logger) Logf(format string, args ...interface{}) error {
+	return l.output(Info, 1+l.skip, format, args)
+}
+
+// Return an independent copy of the logger, e.g.
+//
+//	child := parent.Clone().WithPrefix("net: ").WithStyle(dbg.FileLine)
+//
+// The copy shares the parent's writer by reference unless replaced with
+// WithWriter.
+func (l *Logger) Clone() *Logger {
+	c := *l
+	return &c
+}
+
+// Return a copy of the logger with the given style.
+func (l *Logger) WithStyle(style Style) *Logger {
+	c := *l
+	c.style = style
+	return &c
+}
+
+// Return a copy of the logger that prints to w or, if nil, including a typed
+// nil pointer, the style's writer.
+func (l *Logger) WithWriter(w io.Writer) *Logger {
+	c := *l
+	c.w = box(w).Writer
+	return &c
+}
+
+// Return a copy of the logger with p appended to its message prefix.
+func (l *Logger) WithPrefix(p string) *Logger {
+	c := *l
+	c.prefix += p
+	return &c
+}
+
+// Return a copy of the logger that reports the caller skip frames above
+// that of Log or Logf, like Style.LogDepth.
+func (l *Logger) WithSkip(skip int) *Logger {
+	c := *l
+	c.skip = skip
+	return &c
+}
+
+// Return a copy of the logger that appends the logfmt rendered key/value
+// pairs to each message, e.g.
+//
+//	l.With("req", 123).Log("done") // done req=123
+//
+// An odd, trailing key has the value MISSING.
+func (l *Logger) With(keyvals ...interface{}) *Logger {
+	c := *l
+	c.fields += logfmt(keyvals)
+	return &c
+}
+
+// Write the styled line of the caller skip frames above that of the output
+// call to the logger's writer or, if nil, that of its style.
+func (l *Logger) output(level Level, skip int, format string,
+	args []interface{}) error {
+	if len(args) == 0 || args[0] == nil {
+		return nil
+	}
+	err := errarg(args)
+	if !l.style.EnabledLevel(level) {
+		return err
+	}
+	var frame runtime.Frame
+	if l.framed() {
+		frame = caller(skip + 1)
+	}
+	l.write(level, frame, format, err, args)
+	return err
+}
+
+// Return whether output needs the caller frame for the style prefix, delta,
+// dedup, or filters. Plain and Time styles otherwise skip the costly lookup.
+func (l *Logger) framed() bool {
+	return l.style.framed() || deltaing() || deduping() ||
+		currentFilter() != nil || pkgfiltering()
+}
+
+// Write the styled line of the given level and caller frame to the logger's
+// writer or, if nil, that of its style, and return any write error.
+func (l *Logger) write(level Level, frame runtime.Frame, format string,
+	err error, args []interface{}) error {
+	_, werr := l.writen(level, frame, format, err, args)
+	return werr
+}
+
+// Like write, but also return the number of bytes written.
+func (l *Logger) writen(level Level, frame runtime.Frame, format string,
+	err error, args []interface{}) (int, error) {
+	w := l.w
+	if w == nil {
+		if err != nil && atomic.LoadInt32(&errorsToErr) != 0 {
+			w = CurrentErrorWriter()
+		} else {
+			w = l.style.dest()
+		}
+	}
+	if !pkgenabled(frame.Function) {
+		return 0, nil
+	}
+	keep, collapse := currentFilter(), deduping()
+	if keep != nil || collapse {
+		msg := l.prefix + message(format, args) + l.fields
+		if keep != nil && !keep(l.style, frame.File, frame.Line, msg) {
+			return 0, nil
+		}
+		if collapse && l.repeated(level, frame, w, msg) {
+			return 0, nil
+		}
+	}
+	line, msg := l.sprint(level, frame, colorize(w), format, err, args)
+	var n int
+	var werr error
+	if lw, ok := w.(LevelWriter); ok {
+		n, werr = lw.WriteLevel(level.String(), line)
+	} else {
+		n, werr = io.WriteString(w, line)
+	}
+	if werr != nil {
+		fallbackWrite(w, line, werr)
+	}
+	for _, f := range currentObservers() {
+		f(l.style, msg)
+	}
+	return n, werr
+}
+
+// A LevelWriter is a writer with a severity for each line, such as that of
+// the dbgeventlog module. Its level is the Level name, e.g. "Warn", so that
+// it needn't import dbg.
+type LevelWriter interface {
+	io.Writer
+	WriteLevel(level, line string) (int, error)
+}
+
+// Return the styled line of the given level and caller frame, and its
+// redacted and truncated message.
+func (l *Logger) sprint(level Level, frame runtime.Frame, color bool,
+	format string, err error, args []interface{}) (string, string) {
+	msg := indentation() + redact(l.prefix+
+		truncate(message(format, expandargs(err, args)))+l.fields) +
+		errstack(err)
+	var line string
+	switch seq := l.seqno(); l.style {
+	case JSON:
+		line = jsonline(level, frame, msg, err, seq)
+	case Logfmt:
+		line = logfmtline(frame, msg, err, seq)
+	default:
+		p := seqPrefix(seq) + procPrefix() + elapsedPrefix() +
+			goroutinePrefix() + l.style.prefix(frame) +
+			deltaPrefix(frame.PC, l.dry)
+		if color {
+			line = colored(level, p, msg)
+		} else {
+			line = p + msg
+		}
+	}
+	if l.partial {
+		return line, msg
+	}
+	return line + lineEnding(), msg
+}
+
+// Return the key/value pairs as " k=v ..." with logfmt quoting.
+func logfmt(keyvals []interface{}) string {
+	var sb strings.Builder
+	for i := 0; i < len(keyvals); i += 2 {
+		var v interface{} = "MISSING"
+		if i+1 < len(keyvals) {
+			v = keyvals[i+1]
+		}
+		sb.WriteByte(' ')
+		sb.WriteString(logfmtQuote(fmt.Sprint(keyvals[i])))
+		sb.WriteByte('=')
+		sb.WriteString(logfmtQuote(fmt.Sprint(v)))
+	}
+	return sb.String()
+}
+
+func logfmtQuote(s string) string {
+	if len(s) == 0 || strings.ContainsAny(s, " =\"\\") ||
+		strings.IndexFunc(s, func(r rune) bool {
+			return r < ' ' || r == 0x7f
+		}) >= 0 {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
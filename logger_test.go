@@ -0,0 +1,150 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestLogger(t *testing.T) {
+	defer Writer(CurrentWriter())
+	def := new(bytes.Buffer)
+	Writer(def)
+	a, b := new(bytes.Buffer), new(bytes.Buffer)
+	la := NewLogger(Plain, a).WithPrefix("[a] ")
+	lb := NewLogger(FileLine, b).WithPrefix("[b] ")
+	lab := la.WithPrefix("[ab] ")
+	_, _, line, _ := runtime.Caller(0)
+	la.Log("one")
+	lb.Logf("%s", "two")
+	lab.Log("three")
+	if err := la.Log(os.ErrInvalid); err != os.ErrInvalid {
+		t.Error("Log didn't return error")
+	}
+	NewLogger(Plain, nil).Log("default")
+	if got, want := a.String(),
+		"[a] one\n[a] [ab] three\n[a] invalid argument\n"; got != want {
+		t.Errorf("a got %q, want %q", got, want)
+	}
+	if got, want := b.String(),
+		fmt.Sprint("logger_test.go:", line+2, ": [b] two\n"); got != want {
+		t.Errorf("b got %q, want %q", got, want)
+	}
+	if got, want := def.String(), "default\n"; got != want {
+		t.Errorf("default got %q, want %q", got, want)
+	}
+}
+
+func loggerWrapper(l *Logger, s string) {
+	l.Log(s)
+}
+
+func TestLoggerWithSkip(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := NewLogger(FileLine, buf).WithSkip(1)
+	_, _, line, _ := runtime.Caller(0)
+	loggerWrapper(l, "wrapped")
+	want := fmt.Sprint("logger_test.go:", line+1, ": wrapped\n")
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestLoggerWith(t *testing.T) {
+	buf := new(bytes.Buffer)
+	l := NewLogger(Plain, buf).WithPrefix("[x] ")
+	req := l.With("req", 123)
+	req.With("user", "jane doe", "quote", `a"b`).Log("first")
+	req.Logf("%s", "second")
+	l.With("odd").With("", "empty").Log("third")
+	l.Log("none")
+	want := `[x] first req=123 user="jane doe" quote="a\"b"
+[x] second req=123
+[x] third odd=MISSING ""=empty
+[x] none
+`
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestStyleWithPrefix(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	cache := FileLine.WithPrefix("[cache] ")
+	_, _, line, _ := runtime.Caller(0)
+	cache.Log("miss")
+	err := cache.WithPrefix("[lru] ").Logf("%v", os.ErrNotExist)
+	if err != os.ErrNotExist {
+		t.Error("Logf didn't return error")
+	}
+	want := fmt.Sprint("logger_test.go:", line+1, ": [cache] miss\n",
+		"logger_test.go:", line+2, ": [cache] [lru] ",
+		os.ErrNotExist, "\n")
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestLoggerClone(t *testing.T) {
+	a, b := new(bytes.Buffer), new(bytes.Buffer)
+	parent := NewLogger(Plain, a).WithPrefix("[p] ").WithSkip(0)
+	child := parent.Clone().WithPrefix("[c] ").WithStyle(FileLine).
+		WithSkip(1).WithWriter(b)
+	if *parent != (Logger{style: Plain, w: a, prefix: "[p] "}) {
+		t.Errorf("parent got %+v", *parent)
+	}
+	parent.Log("parent")
+	func() { child.Log("child") }()
+	_, _, line, _ := runtime.Caller(0)
+	if got, want := a.String(), "[p] parent\n"; got != want {
+		t.Errorf("parent got %q, want %q", got, want)
+	}
+	if got, want := b.String(),
+		fmt.Sprint("logger_test.go:", line-1, ": [p] [c] child\n"); got != want {
+		t.Errorf("child got %q, want %q", got, want)
+	}
+	if clone := parent.Clone(); clone == parent || *clone != *parent {
+		t.Error("Clone not an equal copy")
+	}
+}
+
+type levelBuffer struct{ bytes.Buffer }
+
+func (b *levelBuffer) WriteLevel(level, line string) (int, error) {
+	return fmt.Fprint(&b.Buffer, level, " ", line)
+}
+
+func TestLevelWriter(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(levelBuffer)
+	Writer(buf)
+	Plain.Log("info")
+	Plain.Warnf("%s", "warn")
+	if got, want := buf.String(), "Info info\nWarn warn\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestLoggerTypedNil(t *testing.T) {
+	defer Writer(CurrentWriter())
+	defer func(l *Logger) { defaultLogger.l.Store(l) }(DefaultLogger())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	var nilbuf *bytes.Buffer
+	NewLogger(Plain, nilbuf).Log("new")
+	NewLogger(Plain, new(bytes.Buffer)).WithWriter(nilbuf).Log("with")
+	SetDefaultStyle(Plain)
+	SetDefaultWriter(nilbuf)
+	Log("default")
+	if got, want := buf.String(), "new\nwith\ndefault\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
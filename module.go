@@ -0,0 +1,61 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+type module struct {
+	dir, path string
+}
+
+// Directory to nearest module cache.
+var modules sync.Map
+
+// Return the module import path joined with the slash separated path
+// relative to the directory of the nearest go.mod; or, if path isn't within
+// a module, an empty string.
+func relmodule(path string) string {
+	if !filepath.IsAbs(path) {
+		return ""
+	}
+	m := findmodule(filepath.Dir(path))
+	if len(m.path) == 0 {
+		return ""
+	}
+	rel, err := filepath.Rel(m.dir, path)
+	if err != nil {
+		return ""
+	}
+	return m.path + "/" + filepath.ToSlash(rel)
+}
+
+func findmodule(dir string) module {
+	if v, ok := modules.Load(dir); ok {
+		return v.(module)
+	}
+	var m module
+	if b, err := os.ReadFile(filepath.Join(dir, "go.mod")); err == nil {
+		m = module{dir, modulepath(b)}
+	} else if parent := filepath.Dir(dir); parent != dir {
+		m = findmodule(parent)
+	}
+	modules.Store(dir, m)
+	return m
+}
+
+func modulepath(gomod []byte) string {
+	for _, line := range strings.Split(string(gomod), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 1 && fields[0] == "module" {
+			return strings.Trim(fields[1], "\"`")
+		}
+	}
+	return ""
+}
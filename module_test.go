@@ -0,0 +1,234 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"fmt"
+	"go/build"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRelModule(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "go.mod"),
+		[]byte("// comment\nmodule example.com/m\n\ngo 1.11\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, "pkg", "sub", "file.go")
+	want := "example.com/m/pkg/sub/file.go"
+	if got := relmodule(file); got != want {
+		t.Errorf("relmodule got %q, want %q", got, want)
+	}
+	if got := relfile(file); got != want {
+		t.Errorf("relfile got %q, want %q", got, want)
+	}
+}
+
+func TestRelGopath(t *testing.T) {
+	file := filepath.Join(gopathsrcs()[0], "example.com", "legacy", "file.go")
+	want := filepath.Join("example.com", "legacy", "file.go")
+	if got := relgopath(file); got != want {
+		t.Errorf("relgopath got %q, want %q", got, want)
+	}
+	if relmodule(file) == "" {
+		if got := relfile(file); got != want {
+			t.Errorf("relfile got %q, want %q", got, want)
+		}
+	}
+}
+
+func TestRelFileEmpty(t *testing.T) {
+	defer func(f func(string, string) (string, error)) { relpath = f }(relpath)
+	relpath = func(string, string) (string, error) { return "", nil }
+	file := filepath.Join(gopathsrcs()[0], "example.com", "empty", "file.go")
+	if got := relfile(file); len(got) == 0 {
+		t.Error("empty path")
+	}
+}
+
+func TestSetPathBase(t *testing.T) {
+	defer SetPathBase("")
+	file := filepath.Join(wd(), "sub", "file.go")
+	SetPathBase(filepath.Dir(wd()))
+	want := filepath.Base(wd()) + "/sub/file.go"
+	if got := relfile(file); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	SetPathBase(filepath.Join(wd(), "sub"))
+	if got := relfile(file); got != "file.go" {
+		t.Errorf("got %q, want file.go", got)
+	}
+	SetPathBase("")
+	if got := relfile(file); got != "sub/file.go" {
+		t.Errorf("got %q, want sub/file.go", got)
+	}
+}
+
+func TestRefreshWorkingDir(t *testing.T) {
+	orig := wd()
+	defer RefreshWorkingDir()
+	defer os.Chdir(orig)
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, "sub", "file.go")
+	if wd() != orig {
+		t.Fatal("wd changed without refresh")
+	}
+	if got := RefreshWorkingDir(); got != dir {
+		t.Fatalf("got %q, want %q", got, dir)
+	}
+	if got := relfile(file); got != "sub/file.go" {
+		t.Errorf("got %q, want sub/file.go", got)
+	}
+}
+
+func TestSetCaching(t *testing.T) {
+	orig := wd()
+	defer SetCaching(true)
+	defer os.Chdir(orig)
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetCaching(false)
+	if err = os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	if got := wd(); got != dir {
+		t.Errorf("uncached wd got %q, want %q", got, dir)
+	}
+	t.Setenv("GOPATH", filepath.Join(dir, "gopath"))
+	want := []string{filepath.Join(dir, "gopath", "src")}
+	if got := gopathsrcs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("uncached gopathsrcs got %q, want %q", got, want)
+	}
+	SetCaching(true)
+	if got := wd(); got != orig {
+		t.Errorf("cached wd got %q, want %q", got, orig)
+	}
+}
+
+func TestRelSrcList(t *testing.T) {
+	root := string(filepath.Separator)
+	first := filepath.Join(root, "first", "src")
+	second := filepath.Join(root, "second", "src")
+	srcs := []string{first, second}
+	for _, x := range []struct{ file, want string }{
+		{
+			filepath.Join(first, "a", "f.go"),
+			filepath.Join("a", "f.go"),
+		},
+		{
+			filepath.Join(second, "b", "f.go"),
+			filepath.Join("b", "f.go"),
+		},
+		{
+			filepath.Join(root, "other", "f.go"),
+			filepath.Join("..", "..", "other", "f.go"),
+		},
+		{
+			"example.com/trimmed/f.go",
+			"example.com/trimmed/f.go",
+		},
+	} {
+		if got := relsrc(srcs, x.file); got != x.want {
+			t.Errorf("relsrc(%q) = %q, want %q", x.file, got, x.want)
+		}
+	}
+}
+
+func TestRelGoroot(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	fmt.Fprint(FileLine.AsWriter(), "from fmt")
+	if !strings.HasPrefix(buf.String(), "std:fmt/print.go:") {
+		t.Errorf("%q", buf)
+	}
+	file := filepath.Join(build.Default.GOROOT, "src", "net", "http",
+		"server.go")
+	if got, want := relfile(file), "std:net/http/server.go"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSetTrimPrefixes(t *testing.T) {
+	defer SetTrimPrefixes()
+	root := filepath.Join(string(filepath.Separator), "src", "mono")
+	file := filepath.Join(root, "vendor", "x", "f.go")
+	SetTrimPrefixes(filepath.Join(root, "vendor"), root)
+	if got := relfile(file); got != "x/f.go" {
+		t.Errorf("got %q, want x/f.go", got)
+	}
+	SetTrimPrefixes(root, filepath.Join(root, "vendor"))
+	if got := relfile(file); got != "vendor/x/f.go" {
+		t.Errorf("got %q, want vendor/x/f.go", got)
+	}
+	SetTrimPrefixes(root + string(filepath.Separator))
+	if got := abstrimfile(file); got != "vendor/x/f.go" {
+		t.Errorf("got %q, want vendor/x/f.go", got)
+	}
+	SetTrimPrefixes(filepath.Join(root, "ven"))
+	if got := abstrimfile(file); got != file {
+		t.Errorf("partial name match got %q", got)
+	}
+	SetTrimPrefixes(wd())
+	buf := new(bytes.Buffer)
+	NewLogger(AbsFile, buf).Log("trimmed")
+	if !strings.HasPrefix(buf.String(), "module_test.go:") {
+		t.Errorf("AbsFile got %q", buf)
+	}
+}
+
+func TestRelfileSymlink(t *testing.T) {
+	defer SetPathBase("")
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	real := filepath.Join(dir, "real")
+	if err = os.MkdirAll(filepath.Join(real, "pkg"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(real, "pkg", "file.go")
+	if err = os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "link")
+	if err = os.Symlink(real, link); err != nil {
+		t.Skip(err)
+	}
+	SetPathBase(real)
+	got := relfile(filepath.Join(link, "pkg", "file.go"))
+	if got != "pkg/file.go" {
+		t.Errorf("symlink got %q, want pkg/file.go", got)
+	}
+}
+
+func TestRelfileVendor(t *testing.T) {
+	defer SetPathBase("")
+	root := string(filepath.Separator)
+	proj := filepath.Join(root, "home", "user", "proj")
+	SetPathBase(proj)
+	file := filepath.Join(proj, "vendor", "github.com", "x", "y", "f.go")
+	if got := relfile(file); got != "github.com/x/y/f.go" {
+		t.Errorf("vendor got %q, want github.com/x/y/f.go", got)
+	}
+	file = filepath.Join(proj, "a", "vendor", "example.com", "z", "f.go")
+	if got := relfile(file); got != "example.com/z/f.go" {
+		t.Errorf("nested vendor got %q, want example.com/z/f.go", got)
+	}
+}
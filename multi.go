@@ -0,0 +1,50 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"io"
+	"sync"
+)
+
+type multiWriter struct {
+	mu  sync.Mutex
+	ws  []io.Writer
+	err error
+}
+
+// Return a writer that duplicates each write to all of ws, like
+// io.MultiWriter; however, a write stops at the first error. The returned
+// writer records the first such error for retrieval with,
+//
+//	w.(interface{ Err() error }).Err()
+func MultiWriter(ws ...io.Writer) io.Writer {
+	return &multiWriter{ws: append([]io.Writer(nil), ws...)}
+}
+
+func (mw *multiWriter) Write(b []byte) (int, error) {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+	for _, w := range mw.ws {
+		n, err := w.Write(b)
+		if err == nil && n != len(b) {
+			err = io.ErrShortWrite
+		}
+		if err != nil {
+			if mw.err == nil {
+				mw.err = err
+			}
+			return n, err
+		}
+	}
+	return len(b), nil
+}
+
+// Return the first write error, if any.
+func (mw *multiWriter) Err() error {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+	return mw.err
+}
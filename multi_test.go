@@ -0,0 +1,41 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+type errWriter struct{ err error }
+
+func (w errWriter) Write([]byte) (int, error) { return 0, w.err }
+
+func TestMultiWriter(t *testing.T) {
+	defer Writer(CurrentWriter())
+	a, b := new(bytes.Buffer), new(bytes.Buffer)
+	Writer(MultiWriter(a, b))
+	Plain.Log("tee")
+	if a.String() != "tee\n" || b.String() != "tee\n" {
+		t.Fatalf("got %q and %q", a, b)
+	}
+
+	a.Reset()
+	b.Reset()
+	mw := MultiWriter(a, errWriter{os.ErrClosed}, b)
+	Writer(mw)
+	Plain.Log("first")
+	Plain.Log("second")
+	if a.String() != "first\nsecond\n" || b.Len() != 0 {
+		t.Fatalf("got %q and %q", a, b)
+	}
+	if err := mw.(interface{ Err() error }).Err(); err != os.ErrClosed {
+		t.Fatal("Err:", err)
+	}
+	if _, err := mw.Write(nil); err != os.ErrClosed {
+		t.Fatal("Write:", err)
+	}
+}
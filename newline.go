@@ -0,0 +1,22 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import "sync/atomic"
+
+var newline atomic.Value
+
+// Atomic change of the string ending each line, e.g. "\r\n" for some
+// Windows consumers; an empty s restores the default, "\n".
+func SetLineEnding(s string) {
+	newline.Store(s)
+}
+
+func lineEnding() string {
+	if s, _ := newline.Load().(string); len(s) > 0 {
+		return s
+	}
+	return "\n"
+}
@@ -0,0 +1,32 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLineEnding(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	Plain.Log("default")
+	SetLineEnding("\r\n")
+	defer SetLineEnding("")
+	Plain.Log("log")
+	Plain.Logf("%s", "logf")
+	JSON.Log("json")
+	SetLineEnding("")
+	Plain.Log("restored")
+	want := "default\nlog\r\nlogf\r\n" + `{"file":"newline_test.go",`
+	if got := buf.String(); !bytes.HasPrefix(buf.Bytes(), []byte(want)) {
+		t.Fatalf("got %q, want prefix %q", got, want)
+	}
+	if got := buf.String(); !bytes.HasSuffix(buf.Bytes(),
+		[]byte("\"msg\":\"json\"}\r\nrestored\n")) {
+		t.Errorf("got %q", got)
+	}
+}
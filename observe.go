@@ -0,0 +1,31 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var observers struct {
+	sync.Mutex
+	list atomic.Value
+}
+
+// Append a func called with the style and message, as redacted and
+// truncated, of each written line, e.g. to count lines by style. Observers
+// run synchronously, in registration order, after the write, so they should
+// be quick.
+func RegisterObserver(f func(style Style, msg string)) {
+	observers.Lock()
+	defer observers.Unlock()
+	list, _ := observers.list.Load().([]func(Style, string))
+	observers.list.Store(append(list[:len(list):len(list)], f))
+}
+
+func currentObservers() []func(Style, string) {
+	list, _ := observers.list.Load().([]func(Style, string))
+	return list
+}
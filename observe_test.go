@@ -0,0 +1,71 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestObserver(t *testing.T) {
+	defer Writer(CurrentWriter())
+	Writer(new(bytes.Buffer))
+	list := currentObservers()
+	defer observers.list.Store(list)
+	observers.list.Store(([]func(Style, string))(nil))
+
+	counts := make(map[Style]int)
+	var msgs []string
+	RegisterObserver(func(style Style, msg string) { counts[style]++ })
+	RegisterObserver(func(style Style, msg string) {
+		msgs = append(msgs, msg)
+	})
+	Plain.Log("one")
+	FileLine.Log("two")
+	FileLine.Logf("%s", "three")
+	NoOp.Log("none")
+	wantCounts := map[Style]int{Plain: 1, FileLine: 2}
+	if !reflect.DeepEqual(counts, wantCounts) {
+		t.Errorf("counts %v, want %v", counts, wantCounts)
+	}
+	wantMsgs := []string{"one", "two", "three"}
+	if !reflect.DeepEqual(msgs, wantMsgs) {
+		t.Errorf("msgs %q, want %q", msgs, wantMsgs)
+	}
+}
+
+func TestObserverRedacted(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	list := currentObservers()
+	defer observers.list.Store(list)
+	observers.list.Store(([]func(Style, string))(nil))
+	redacting, _ := redactors.list.Load().([]func(string) string)
+	defer redactors.list.Store(redacting)
+	redactors.list.Store([]func(string) string(nil))
+	defer SetMaxLen(0)
+
+	var msgs []string
+	RegisterObserver(func(style Style, msg string) {
+		msgs = append(msgs, msg)
+	})
+	RegisterRedactor(func(s string) string {
+		return strings.Replace(s, "hunter2", "***", -1)
+	})
+	Plain.Log("pw=hunter2")
+	SetMaxLen(5)
+	Plain.Log("pw=hunter2")
+	wantMsgs := []string{"pw=***", "pw=hu …(truncated, 5 bytes)"}
+	if !reflect.DeepEqual(msgs, wantMsgs) {
+		t.Errorf("msgs %q, want %q", msgs, wantMsgs)
+	}
+	got, want := buf.String(), strings.Join(wantMsgs, "\n")+"\n"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
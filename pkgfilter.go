@@ -0,0 +1,91 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+type pkgset map[string]bool
+
+var packages struct {
+	sync.Mutex
+	// copy-on-write sets
+	allow, deny atomic.Value
+	filtering   int32
+}
+
+// Add the package import path to the allowlist and remove it from the
+// denylist. While the allowlist isn't empty, only its packages print.
+func EnablePackage(importPath string) {
+	storePackage(importPath, true)
+}
+
+// Add the package import path to the denylist and remove it from the
+// allowlist. Packages of the denylist don't print.
+func DisablePackage(importPath string) {
+	storePackage(importPath, false)
+}
+
+func storePackage(importPath string, enable bool) {
+	packages.Lock()
+	defer packages.Unlock()
+	allow, deny := loadpkgset(&packages.allow), loadpkgset(&packages.deny)
+	if enable {
+		allow, deny = allow.with(importPath), deny.without(importPath)
+	} else {
+		allow, deny = allow.without(importPath), deny.with(importPath)
+	}
+	packages.allow.Store(allow)
+	packages.deny.Store(deny)
+	var v int32
+	if len(allow) > 0 || len(deny) > 0 {
+		v = 1
+	}
+	atomic.StoreInt32(&packages.filtering, v)
+}
+
+func loadpkgset(v *atomic.Value) pkgset {
+	set, _ := v.Load().(pkgset)
+	return set
+}
+
+func (set pkgset) with(importPath string) pkgset {
+	c := make(pkgset, len(set)+1)
+	for k := range set {
+		c[k] = true
+	}
+	c[importPath] = true
+	return c
+}
+
+func (set pkgset) without(importPath string) pkgset {
+	c := make(pkgset, len(set))
+	for k := range set {
+		if k != importPath {
+			c[k] = true
+		}
+	}
+	return c
+}
+
+// Return whether either package list isn't empty.
+func pkgfiltering() bool {
+	return atomic.LoadInt32(&packages.filtering) != 0
+}
+
+// Return whether the package of the qualified function name may print.
+func pkgenabled(function string) bool {
+	if !pkgfiltering() {
+		return true
+	}
+	pkg := pkgname(function)
+	if loadpkgset(&packages.deny)[pkg] {
+		return false
+	}
+	allow := loadpkgset(&packages.allow)
+	return len(allow) == 0 || allow[pkg]
+}
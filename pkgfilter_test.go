@@ -0,0 +1,72 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// Log the message as if from the package of the function.
+func logFrom(fn interface{}, msg string) {
+	pc := reflect.ValueOf(fn).Pointer() + 1
+	prev := callerFn
+	defer func() { callerFn = prev }()
+	callerFn = func(int) (uintptr, string, int, bool) {
+		return pc, "/src/file.go", 1, true
+	}
+	Plain.Log(msg)
+}
+
+func resetPackages() {
+	packages.allow.Store(pkgset(nil))
+	packages.deny.Store(pkgset(nil))
+	atomic.StoreInt32(&packages.filtering, 0)
+}
+
+func TestPackageFilter(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	defer resetPackages()
+	logBoth := func() {
+		logFrom(stubbedCaller, "dbg")
+		logFrom(strings.ToUpper, "strings")
+	}
+	logBoth()
+	DisablePackage("strings")
+	logBoth()
+	EnablePackage("strings")
+	logBoth()
+	EnablePackage("github.com/platinasystems/dbg")
+	logBoth()
+	DisablePackage("github.com/platinasystems/dbg")
+	logBoth()
+	want := "dbg\nstrings\n" +
+		"dbg\n" +
+		"strings\n" +
+		"dbg\nstrings\n" +
+		"strings\n"
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestPackageFilterDotted(t *testing.T) {
+	defer resetPackages()
+	const f, g = "example.com/m/lib%2ev2.F", "gopkg.in/yaml%2ev3.(*T).M"
+	EnablePackage("example.com/m/lib.v2")
+	if !pkgenabled(f) || pkgenabled(g) {
+		t.Error("allowlist of example.com/m/lib.v2")
+	}
+	resetPackages()
+	DisablePackage("gopkg.in/yaml.v3")
+	if !pkgenabled(f) || pkgenabled(g) {
+		t.Error("denylist of gopkg.in/yaml.v3")
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	showPID  int32
+	showHost int32
+	hostname struct {
+		once sync.Once
+		name string
+	}
+)
+
+// Atomic change of whether each line begins with the process ID, e.g.
+// "pid=1234 ".
+func SetShowPID(enable bool) {
+	var v int32
+	if enable {
+		v = 1
+	}
+	atomic.StoreInt32(&showPID, v)
+}
+
+// Atomic change of whether each line begins with the hostname, e.g.
+// "host=example ". The name is resolved once, by the first line that
+// shows it.
+func SetShowHost(enable bool) {
+	var v int32
+	if enable {
+		v = 1
+	}
+	atomic.StoreInt32(&showHost, v)
+}
+
+// Return the cached os.Hostname, or, on error, "unknown".
+func host() string {
+	hostname.once.Do(func() {
+		name, err := os.Hostname()
+		if err != nil || len(name) == 0 {
+			name = "unknown"
+		}
+		hostname.name = name
+	})
+	return hostname.name
+}
+
+func procPrefix() string {
+	var s string
+	if atomic.LoadInt32(&showPID) != 0 {
+		s += "pid=" + strconv.Itoa(os.Getpid()) + " "
+	}
+	if atomic.LoadInt32(&showHost) != 0 {
+		s += "host=" + host() + " "
+	}
+	return s
+}
@@ -0,0 +1,51 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestShowPIDHost(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	defer SetShowPID(false)
+	defer SetShowHost(false)
+	SetShowPID(true)
+	Plain.Log("pid")
+	SetShowHost(true)
+	Pkg.Log("both")
+	SetShowPID(false)
+	Plain.Log("host")
+	name, err := os.Hostname()
+	if err != nil {
+		name = "unknown"
+	}
+	if h := host(); h != name {
+		t.Errorf("cached host %q, want %q", h, name)
+	}
+	pid := os.Getpid()
+	want := fmt.Sprint("pid=", pid, " pid\n",
+		"pid=", pid, " host=", name, " github.com/platinasystems/dbg: both\n",
+		"host=", name, " host\n")
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+	buf.Reset()
+	SetShowPID(true)
+	JSON.Log("json")
+	var v jsonLine
+	if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.PID != pid || v.Host != name {
+		t.Errorf("JSON got pid %d host %q", v.PID, v.Host)
+	}
+}
@@ -0,0 +1,18 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+// Set the style variable to s and return a func that restores its previous
+// value, e.g.
+//
+//	defer dbg.Err.Push(dbg.FileLine)()
+//
+// This mutates the pointed-to variable without synchronization so, like
+// any assignment, it races with goroutines using that style.
+func (style *Style) Push(s Style) func() {
+	prev := *style
+	*style = s
+	return func() { *style = prev }
+}
@@ -0,0 +1,31 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPush(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	style := NoOp
+	func() {
+		defer style.Push(Plain)()
+		if style != Plain {
+			t.Errorf("during got %v, want %v", style, Plain)
+		}
+		style.Log("elevated")
+	}()
+	if style != NoOp {
+		t.Errorf("after got %v, want %v", style, NoOp)
+	}
+	style.Log("restored")
+	if got, want := buf.String(), "elevated\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var redactors struct {
+	sync.Mutex
+	list atomic.Value
+}
+
+// Append a func that rewrites each formatted message before it's written,
+// e.g. to mask secrets. Redactors run in registration order. The error
+// returned by Log and Logf is unchanged.
+func RegisterRedactor(f func(string) string) {
+	redactors.Lock()
+	defer redactors.Unlock()
+	list, _ := redactors.list.Load().([]func(string) string)
+	redactors.list.Store(append(list[:len(list):len(list)], f))
+}
+
+func redact(s string) string {
+	list, _ := redactors.list.Load().([]func(string) string)
+	for _, f := range list {
+		s = f(s)
+	}
+	return s
+}
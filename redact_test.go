@@ -0,0 +1,36 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRedactor(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	list, _ := redactors.list.Load().([]func(string) string)
+	defer redactors.list.Store(list)
+	redactors.list.Store([]func(string) string(nil))
+
+	password := regexp.MustCompile(`password=\S+`)
+	RegisterRedactor(func(s string) string {
+		return password.ReplaceAllString(s, "password=***")
+	})
+	RegisterRedactor(strings.ToUpper)
+	errLogin := errors.New("login user=bob password=hunter2")
+	if err := Plain.Log(errLogin); err != errLogin {
+		t.Errorf("returned %v, want original error", err)
+	}
+	if got, want := buf.String(),
+		"LOGIN USER=BOB PASSWORD=***\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"strings"
+	"sync"
+)
+
+// RingWriter retains the most recent lines written to it.
+type RingWriter struct {
+	mu      sync.Mutex
+	lines   []string
+	next    int
+	full    bool
+	partial strings.Builder
+}
+
+// Return a RingWriter that retains the last n lines, e.g. to dump the
+// debug output leading up to an error,
+//
+//	ring := dbg.NewRingWriter(100)
+//	dbg.Writer(dbg.MultiWriter(os.Stdout, ring))
+func NewRingWriter(n int) *RingWriter {
+	if n < 1 {
+		n = 1
+	}
+	return &RingWriter{lines: make([]string, n)}
+}
+
+func (rw *RingWriter) Write(b []byte) (int, error) {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	s := string(b)
+	for {
+		i := strings.IndexByte(s, '\n')
+		if i < 0 {
+			rw.partial.WriteString(s)
+			break
+		}
+		rw.partial.WriteString(s[:i])
+		rw.lines[rw.next] = rw.partial.String()
+		rw.partial.Reset()
+		if rw.next++; rw.next == len(rw.lines) {
+			rw.next, rw.full = 0, true
+		}
+		s = s[i+1:]
+	}
+	return len(b), nil
+}
+
+// Return the retained lines, less newlines, oldest first. A line without
+// its newline isn't included until completed.
+func (rw *RingWriter) Lines() []string {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	if !rw.full {
+		return append([]string(nil), rw.lines[:rw.next]...)
+	}
+	lines := make([]string, 0, len(rw.lines))
+	lines = append(lines, rw.lines[rw.next:]...)
+	return append(lines, rw.lines[:rw.next]...)
+}
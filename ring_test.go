@@ -0,0 +1,55 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestRingWriter(t *testing.T) {
+	defer Writer(CurrentWriter())
+	ring := NewRingWriter(3)
+	Writer(ring)
+	Plain.Log("one")
+	Plain.Log("two")
+	want := []string{"one", "two"}
+	if got := ring.Lines(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	for _, s := range []string{"three", "four", "five"} {
+		Plain.Log(s)
+	}
+	Plain.Logfnn("%s", "partial")
+	want = []string{"three", "four", "five"}
+	if got := ring.Lines(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	Plain.Log(" six")
+	want = []string{"four", "five", "partial six"}
+	if got := ring.Lines(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRingWriterRace(t *testing.T) {
+	ring := NewRingWriter(8)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				ring.Write([]byte("line\n"))
+				ring.Lines()
+			}
+		}()
+	}
+	wg.Wait()
+	if n := len(ring.Lines()); n != 8 {
+		t.Errorf("got %d lines, want 8", n)
+	}
+}
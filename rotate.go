@@ -0,0 +1,107 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+type rotatingWriter struct {
+	mu         sync.Mutex
+	path       string
+	maxBytes   int64
+	maxBackups int
+	f          *os.File
+	size       int64
+}
+
+// Return a writer that appends to the file at path until a write would
+// exceed maxBytes; then it renames the file to path.1, shifting older
+// backups to path.2 and so on, removes those beyond maxBackups, and starts
+// a new file. A write larger than maxBytes still goes whole to a new file.
+// With maxBackups 0, or less, the file is truncated without backups.
+func NewRotatingWriter(path string, maxBytes int64,
+	maxBackups int) (io.WriteCloser, error) {
+	if maxBackups < 0 {
+		maxBackups = 0
+	}
+	w := &rotatingWriter{
+		path:       path,
+		maxBytes:   maxBytes,
+		maxBackups: maxBackups,
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *rotatingWriter) open() error {
+	f, err := os.OpenFile(w.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND,
+		0644)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	w.f, w.size = f, fi.Size()
+	return nil
+}
+
+func (w *rotatingWriter) backup(i int) string {
+	return fmt.Sprint(w.path, ".", i)
+}
+
+func (w *rotatingWriter) rotate() error {
+	if err := w.f.Close(); err != nil {
+		return err
+	}
+	w.f = nil
+	if w.maxBackups > 0 {
+		os.Remove(w.backup(w.maxBackups))
+		for i := w.maxBackups - 1; i > 0; i-- {
+			os.Rename(w.backup(i), w.backup(i+1))
+		}
+		if err := os.Rename(w.path, w.backup(1)); err != nil {
+			return err
+		}
+	} else if err := os.Remove(w.path); err != nil {
+		return err
+	}
+	return w.open()
+}
+
+func (w *rotatingWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.f == nil {
+		return 0, os.ErrClosed
+	}
+	if w.size > 0 && w.size+int64(len(b)) > w.maxBytes {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.f.Write(b)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *rotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.f == nil {
+		return os.ErrClosed
+	}
+	err := w.f.Close()
+	w.f = nil
+	return err
+}
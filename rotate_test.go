@@ -0,0 +1,80 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRotatingWriter(t *testing.T) {
+	defer Writer(CurrentWriter())
+	path := filepath.Join(t.TempDir(), "debug.log")
+	w, err := NewRotatingWriter(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	Writer(w)
+	for _, s := range []string{"one", "two", "three", "four", "five",
+		"six"} {
+		Plain.Log(s)
+	}
+	for name, want := range map[string]string{
+		path:        "six\n",
+		path + ".1": "four\nfive\n",
+		path + ".2": "three\n",
+	} {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			t.Error(err)
+		} else if got := string(b); got != want {
+			t.Errorf("%s got %q, want %q", filepath.Base(name), got,
+				want)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("pruned backup got %v", err)
+	}
+}
+
+func TestRotatingWriterNoBackups(t *testing.T) {
+	defer Writer(CurrentWriter())
+	path := filepath.Join(t.TempDir(), "debug.log")
+	for _, name := range []string{path + ".0", path + ".-1"} {
+		if err := os.WriteFile(name, []byte("other\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, maxBackups := range []int{0, -1} {
+		w, err := NewRotatingWriter(path, 10, maxBackups)
+		if err != nil {
+			t.Fatal(err)
+		}
+		Writer(w)
+		for _, s := range []string{"one", "two", "three"} {
+			Plain.Log(s)
+		}
+		w.Close()
+		if b, err := os.ReadFile(path); err != nil {
+			t.Error(err)
+		} else if got := string(b); got != "three\n" {
+			t.Errorf("%d backups got %q, want %q", maxBackups, got,
+				"three\n")
+		}
+		if _, err := os.Stat(path + ".1"); !os.IsNotExist(err) {
+			t.Errorf("%d backups got backup %v", maxBackups, err)
+		}
+		if err := os.Remove(path); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{path + ".0", path + ".-1"} {
+		if _, err := os.Stat(name); err != nil {
+			t.Errorf("unrelated %s: %v", filepath.Base(name), err)
+		}
+	}
+}
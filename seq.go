@@ -0,0 +1,49 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"strconv"
+	"sync/atomic"
+)
+
+var (
+	showSeq int32
+	seq     uint64
+)
+
+// Atomic change of whether each line begins with its sequence number, e.g.
+// "#18 ", to detect dropped or reordered lines.
+func SetShowSeq(enable bool) {
+	var v int32
+	if enable {
+		v = 1
+	}
+	atomic.StoreInt32(&showSeq, v)
+}
+
+// Restart the sequence so that the next line is #1.
+func ResetSeq() {
+	atomic.StoreUint64(&seq, 0)
+}
+
+// Return the sequence number of the line, or 0 without SetShowSeq. A dry
+// logger, e.g. that of Sprint, peeks at the next number without taking it.
+func (l *Logger) seqno() uint64 {
+	if atomic.LoadInt32(&showSeq) == 0 {
+		return 0
+	}
+	if l.dry {
+		return atomic.LoadUint64(&seq) + 1
+	}
+	return atomic.AddUint64(&seq, 1)
+}
+
+func seqPrefix(n uint64) string {
+	if n == 0 {
+		return ""
+	}
+	return "#" + strconv.FormatUint(n, 10) + " "
+}
@@ -0,0 +1,41 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestShowSeq(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	SetShowSeq(true)
+	defer SetShowSeq(false)
+	ResetSeq()
+	if got, want := Plain.Sprint("peek"), "#1 peek\n"; got != want {
+		t.Errorf("Sprint got %q, want %q", got, want)
+	}
+	Plain.Log("one")
+	Pkg.Log("two")
+	Logfmt.Log("three")
+	ResetSeq()
+	Plain.Log("reset")
+	SetShowSeq(false)
+	Plain.Log("off")
+	want := "#1 one\n" +
+		"#2 github.com/platinasystems/dbg: two\n"
+	if got := buf.String(); !bytes.HasPrefix(buf.Bytes(), []byte(want)) {
+		t.Fatalf("got %q, want prefix %q", got, want)
+	}
+	want = "\n#1 reset\noff\n"
+	if got := buf.String(); !bytes.HasSuffix(buf.Bytes(), []byte(want)) {
+		t.Fatalf("got %q, want suffix %q", got, want)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("\nseq=3 file=")) {
+		t.Errorf("Logfmt got %q", buf)
+	}
+}
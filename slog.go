@@ -0,0 +1,113 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+)
+
+type slogHandler struct {
+	l      Logger
+	opts   slog.HandlerOptions
+	groups string
+}
+
+// Return a slog.Handler that prints records through the style to the dbg
+// writer with the record's caller frame and logfmt rendered attributes,
+// e.g.
+//
+//	slog.New(dbg.NewSlogHandler(dbg.FileLine, nil)).Info("msg", "k", 1)
+//	main.go:22: msg k=1
+//
+// Records below Info are Debug; below Warn, Info; below Error, Warn;
+// otherwise, Error. The handler drops records less than the dbg minimum
+// level or, if set, the opts level. The handler ignores other options.
+func NewSlogHandler(style Style, opts *slog.HandlerOptions) slog.Handler {
+	h := &slogHandler{l: Logger{style: style}}
+	if opts != nil {
+		h.opts = *opts
+	}
+	return h
+}
+
+// Return the dbg level of a slog level.
+func SlogLevel(level slog.Level) Level {
+	switch {
+	case level < slog.LevelInfo:
+		return Debug
+	case level < slog.LevelWarn:
+		return Info
+	case level < slog.LevelError:
+		return Warn
+	}
+	return Error
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	if !h.l.style.EnabledLevel(SlogLevel(level)) {
+		return false
+	}
+	if h.opts.Level != nil && level < h.opts.Level.Level() {
+		return false
+	}
+	return true
+}
+
+func (h *slogHandler) Handle(_ context.Context, r slog.Record) error {
+	var frame runtime.Frame
+	if h.l.framed() && r.PC != 0 {
+		frame, _ = runtime.CallersFrames([]uintptr{r.PC}).Next()
+	}
+	var keyvals []interface{}
+	r.Attrs(func(a slog.Attr) bool {
+		keyvals = appendAttr(keyvals, h.groups, a)
+		return true
+	})
+	l := h.l
+	l.fields += logfmt(keyvals)
+	return l.write(SlogLevel(r.Level), frame, "", nil,
+		[]interface{}{r.Message})
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var keyvals []interface{}
+	for _, a := range attrs {
+		keyvals = appendAttr(keyvals, h.groups, a)
+	}
+	c := *h
+	c.l.fields += logfmt(keyvals)
+	return &c
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if len(name) == 0 {
+		return h
+	}
+	c := *h
+	c.groups += name + "."
+	return &c
+}
+
+// Append the group qualified key and resolved value of the attribute,
+// flattening group values.
+func appendAttr(keyvals []interface{}, groups string,
+	a slog.Attr) []interface{} {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return keyvals
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		if len(a.Key) > 0 {
+			groups += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			keyvals = appendAttr(keyvals, groups, ga)
+		}
+		return keyvals
+	}
+	return append(keyvals, groups+a.Key, a.Value.String())
+}
@@ -0,0 +1,76 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestSlogHandler(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	logger := slog.New(NewSlogHandler(FileLine, nil))
+	_, _, line, _ := runtime.Caller(0)
+	logger.Info("hello", "k", 1, "s", "two words")
+	logger.With("req", 7).WithGroup("g").Warn("grouped",
+		slog.Group("sub", "a", true), "b", "c")
+	logger.Debug("debug")
+	want := fmt.Sprint("slog_test.go:", line+1,
+		`: hello k=1 s="two words"`, "\n",
+		"slog_test.go:", line+2, ": grouped req=7 g.sub.a=true g.b=c\n",
+		"slog_test.go:", line+4, ": debug\n")
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSlogHandlerLevel(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	defer SetMinLevel(MinLevel())
+	SetMinLevel(Warn)
+	logger := slog.New(NewSlogHandler(Plain, nil))
+	logger.Info("dropped")
+	logger.Warn("warn")
+	logger = slog.New(NewSlogHandler(Plain,
+		&slog.HandlerOptions{Level: slog.LevelError}))
+	logger.Warn("dropped")
+	logger.Error("error")
+	slog.New(NewSlogHandler(NoOp, nil)).Error("dropped")
+	if got, want := buf.String(), "warn\nerror\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	for level, want := range map[slog.Level]Level{
+		slog.LevelDebug - 4: Debug,
+		slog.LevelDebug:     Debug,
+		slog.LevelInfo:      Info,
+		slog.LevelWarn + 1:  Warn,
+		slog.LevelError:     Error,
+		slog.LevelError + 8: Error,
+	} {
+		if got := SlogLevel(level); got != want {
+			t.Errorf("SlogLevel(%v) = %v, want %v", level, got, want)
+		}
+	}
+}
+
+func TestSlogHandlerWriteError(t *testing.T) {
+	defer Writer(CurrentWriter())
+	Writer(errWriter{os.ErrClosed})
+	h := NewSlogHandler(Plain, nil)
+	r := slog.NewRecord(time.Time{}, slog.LevelInfo, "lost", 0)
+	if err := h.Handle(context.Background(), r); err != os.ErrClosed {
+		t.Errorf("got %v, want %v", err, os.ErrClosed)
+	}
+}
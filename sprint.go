@@ -0,0 +1,38 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import "runtime"
+
+// Return the line that Log would print, including the newline, without
+// writing it.
+func (style Style) Sprint(args ...interface{}) string {
+	return style.sprintv(Info, "", nil, args...)
+}
+
+// Return the line that Logf would print, including the newline, without
+// writing it.
+func (style Style) Sprintf(format string, args ...interface{}) string {
+	return style.sprintv(Info, format, nil, args...)
+}
+
+// See the log vet work-around.
+func (style Style) sprintv(level Level, format string, _ interface{},
+	args ...interface{}) string {
+	const skip = 2
+	if len(args) == 0 || args[0] == nil {
+		return ""
+	}
+	if !style.EnabledLevel(level) {
+		return ""
+	}
+	var frame runtime.Frame
+	if style.framed() {
+		frame = caller(skip)
+	}
+	l := Logger{style: style, dry: true}
+	line, _ := l.sprint(level, frame, false, format, errarg(args), args)
+	return line
+}
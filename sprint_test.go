@@ -0,0 +1,44 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSprint(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	format, args := "%v %s", []interface{}{os.ErrInvalid, "formatted"}
+	for _, style := range []Style{
+		NoOp,
+		Plain,
+		FileLine,
+		Func,
+		FileLineFunc,
+		JSON,
+	} {
+		buf.Reset()
+		s, _ := style.Sprint("printed"), style.Log("printed")
+		if s != buf.String() {
+			t.Errorf("%v: Sprint %q, Log %q", style, s, buf)
+		}
+		buf.Reset()
+		s, _ = style.Sprintf(format, args...), style.Logf(format, args...)
+		if s != buf.String() {
+			t.Errorf("%v: Sprintf %q, Logf %q", style, s, buf)
+		}
+	}
+	if s := FileLine.Sprint("here"); !strings.HasPrefix(s, "sprint_test.go:") {
+		t.Error("wrong caller:", s)
+	}
+	if s := Plain.Sprint(nil, "not", "printed"); len(s) > 0 {
+		t.Errorf("nil args[0]: %q", s)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+const DefaultStackDepth = 32
+
+var stackDepth int32 = DefaultStackDepth
+
+// Atomic change of the maximum number of frames printed by Stack; n < 1
+// restores DefaultStackDepth.
+func SetStackDepth(n int) {
+	if n < 1 {
+		n = DefaultStackDepth
+	}
+	atomic.StoreInt32(&stackDepth, int32(n))
+}
+
+// Like Log followed by the caller's stack, less dbg frames, e.g.
+//
+//	stack_test.go:22: message
+//	main.main()
+//		/home/user/src/cmd/main.go:22
+func (style Style) Stack(args ...interface{}) error {
+	if style == NoOp {
+		return errarg(args)
+	}
+	l := Logger{style: style, fields: "\n" + stack(1)}
+	return l.output(Info, 1, "", args)
+}
+
+// Return the formatted stack beginning with the caller skip frames above
+// that of the stack call.
+func stack(skip int) string {
+	pcs := make([]uintptr, atomic.LoadInt32(&stackDepth))
+	return stackof(pcs[:runtime.Callers(skip+2, pcs)])
+}
+
+// Return the formatted stack of the program counters.
+func stackof(pcs []uintptr) string {
+	if len(pcs) == 0 {
+		return ""
+	}
+	frames := runtime.CallersFrames(pcs)
+	var sb strings.Builder
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&sb, "%s()\n\t%s:%d\n", frame.Function, frame.File,
+			frame.Line)
+		if !more {
+			break
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,46 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func stackCaller() error {
+	return Plain.Stack(os.ErrInvalid, "message")
+}
+
+func TestStack(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	if err := stackCaller(); err != os.ErrInvalid {
+		t.Fatal("didn't return error")
+	}
+	lines := strings.Split(buf.String(), "\n")
+	if lines[0] != "invalid argument message" {
+		t.Fatalf("%q", lines[0])
+	}
+	if want := "github.com/platinasystems/dbg.stackCaller()"; lines[1] != want {
+		t.Fatalf("got %q, want %q", lines[1], want)
+	}
+	if want := "github.com/platinasystems/dbg.TestStack()"; lines[3] != want {
+		t.Fatalf("got %q, want %q", lines[3], want)
+	}
+	if strings.Contains(buf.String(), "dbg.Style.Stack") {
+		t.Fatalf("dbg frame in\n%s", buf)
+	}
+
+	buf.Reset()
+	SetStackDepth(1)
+	defer SetStackDepth(0)
+	stackCaller()
+	if n := strings.Count(buf.String(), "\n"); n != 3 {
+		t.Fatalf("depth 1 printed %d lines\n%s", n, buf)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"runtime"
+	"strings"
+)
+
+type styleWriter Style
+
+// Return a writer that logs each write, less trailing newline, with the
+// style. Use this to style the standard log package,
+//
+//	log.SetOutput(dbg.FileLine.AsWriter())
+//	log.SetFlags(0)
+//
+// The caller frame of the prefix is that of the first caller outside of the
+// standard log package, so, writes through other wrappers, like fmt.Fprint,
+// report the wrapper.
+func (style Style) AsWriter() io.Writer {
+	return styleWriter(style)
+}
+
+// Return a standard logger that writes through the style to the dbg
+// writer. Its flags are zero since the style has the caller and time
+// prefix in place of log.Lshortfile, log.Llongfile, log.Ldate, and
+// log.Ltime. If set with SetFlags, the standard logger also formats these
+// within the message following the style prefix. The log.SetPrefix prefix
+// precedes the message or, with log.Lmsgprefix, the message less flags.
+func (style Style) NewStdLogger() *log.Logger {
+	return log.New(style.AsWriter(), "", 0)
+}
+
+// Like log.Output, print s, less any trailing newline, with the prefix of
+// the caller calldepth frames above that of Output, so, a calldepth of 1 is
+// the caller of Output. This returns any write error.
+func (style Style) Output(calldepth int, s string) error {
+	if !style.EnabledLevel(Info) {
+		return nil
+	}
+	l := Logger{style: style}
+	var frame runtime.Frame
+	if l.framed() {
+		frame = caller(calldepth)
+	}
+	return l.write(Info, frame, "", nil,
+		[]interface{}{strings.TrimSuffix(s, "\n")})
+}
+
+func (w styleWriter) Write(b []byte) (int, error) {
+	if Style(w) == NoOp {
+		return len(b), nil
+	}
+	l := Logger{style: Style(w)}
+	msg := string(bytes.TrimSuffix(b, []byte("\n")))
+	skip := 1
+	if l.framed() {
+		skip += stdlogskip()
+	}
+	l.output(Info, skip, "", []interface{}{msg})
+	return len(b), nil
+}
+
+// Return the number of standard log package frames above the caller of
+// Write.
+func stdlogskip() int {
+	var pcs [16]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs[:])])
+	n := 0
+	for {
+		frame, more := frames.Next()
+		if !more || !strings.HasPrefix(frame.Function, "log.") {
+			return n
+		}
+		n++
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestAsWriter(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	logger := log.New(FileLine.AsWriter(), "std: ", 0)
+	_, _, line, _ := runtime.Caller(0)
+	logger.Printf("%s", "printf")
+	logger.Println("println")
+	logger.Output(1, "output")
+	want := fmt.Sprint("stdlog_test.go:", line+1, ": std: printf\n",
+		"stdlog_test.go:", line+2, ": std: println\n",
+		"stdlog_test.go:", line+3, ": std: output\n")
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+	buf.Reset()
+	log.New(NoOp.AsWriter(), "", 0).Print("not printed")
+	if buf.Len() > 0 {
+		t.Errorf("NoOp printed %q", buf)
+	}
+}
+
+func TestNewStdLogger(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	logger := Func.NewStdLogger()
+	if flags := logger.Flags(); flags != 0 {
+		t.Error("flags", flags)
+	}
+	logger.Printf("%d", 1)
+	logger.SetPrefix("[p] ")
+	logger.Print("two")
+	want := "github.com/platinasystems/dbg.TestNewStdLogger() 1\n" +
+		"github.com/platinasystems/dbg.TestNewStdLogger() [p] two\n"
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func outputWrapper(s string) error {
+	return FileLine.Output(2, s)
+}
+
+func TestOutput(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	_, _, line, _ := runtime.Caller(0)
+	FileLine.Output(1, "one")
+	outputWrapper("two\n")
+	FileLine.Output(0, "zero")
+	want := fmt.Sprint("stdlog_test.go:", line+1, ": one\n",
+		"stdlog_test.go:", line+2, ": two\n",
+		"stdlog.go:")
+	if got := buf.String(); !strings.HasPrefix(got, want) {
+		t.Errorf("\ngot:\n%s\nwant prefix:\n%s", got, want)
+	}
+	Writer(errWriter{os.ErrClosed})
+	if err := Plain.Output(1, "lost"); err != os.ErrClosed {
+		t.Error("write error:", err)
+	}
+	defer SetMinLevel(MinLevel())
+	SetMinLevel(Warn)
+	if err := Plain.Output(1, "dropped"); err != nil {
+		t.Error("below MinLevel:", err)
+	}
+}
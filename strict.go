@@ -0,0 +1,68 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+const mismatch = "dbg: format/arg mismatch: "
+
+var strictFormat int32
+
+// Atomic change of whether Logf and the like compare the number of format
+// verbs to that of args and, if these differ, prefix the message with,
+//
+//	dbg: format/arg mismatch:
+//
+// The default, false, skips the check.
+func SetStrictFormat(enable bool) {
+	var v int32
+	if enable {
+		v = 1
+	}
+	atomic.StoreInt32(&strictFormat, v)
+}
+
+// Return the mismatch prefix if strict and the format doesn't consume
+// exactly nargs.
+func strict(format string, nargs int) string {
+	if atomic.LoadInt32(&strictFormat) == 0 {
+		return ""
+	}
+	if n, ok := verbs(format); ok && n != nargs {
+		return mismatch
+	}
+	return ""
+}
+
+// Return the number of args consumed by the format; false with explicit
+// arg indexes, e.g. %[1]d, that this doesn't count.
+func verbs(format string) (int, bool) {
+	n := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		for i++; i < len(format) &&
+			strings.IndexByte("+-# 0", format[i]) >= 0; i++ {
+		}
+		for ; i < len(format); i++ {
+			c := format[i]
+			if c == '[' {
+				return 0, false
+			} else if c == '*' {
+				n++
+			} else if c != '.' && (c < '0' || c > '9') {
+				break
+			}
+		}
+		if i < len(format) && format[i] != '%' {
+			n++
+		}
+	}
+	return n, true
+}
@@ -0,0 +1,52 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestStrictFormat(t *testing.T) {
+	defer Writer(CurrentWriter())
+	defer SetStrictFormat(false)
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	Plain.Logf("%d of %d", 1)
+	SetStrictFormat(true)
+	Plain.Logf("%d of %d", 1)
+	Plain.Logf("%d of %d", 1, 2)
+	Plain.Logf("%s", "a", "b")
+	want := "1 of %!d(MISSING)\n" +
+		"dbg: format/arg mismatch: 1 of %!d(MISSING)\n" +
+		"1 of 2\n" +
+		"dbg: format/arg mismatch: a%!(EXTRA string=b)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestVerbs(t *testing.T) {
+	for _, x := range []struct {
+		format string
+		n      int
+		ok     bool
+	}{
+		{"plain", 0, true},
+		{"100%%", 0, true},
+		{"%d %s %v", 3, true},
+		{"%-08.3f|%+q|%#x", 3, true},
+		{"%*d", 2, true},
+		{"%-*.*f", 3, true},
+		{"%[2]d %[1]d", 0, false},
+		{"trailing %", 0, true},
+	} {
+		n, ok := verbs(x.format)
+		if n != x.n || ok != x.ok {
+			t.Errorf("%q got %d, %t; want %d, %t", x.format, n, ok,
+				x.n, x.ok)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestStyleString(t *testing.T) {
+	for _, style := range []Style{-1, nStyles, nStyles + 10} {
+		got := style.String()
+		if want := fmt.Sprint(int(style)); got != want {
+			t.Errorf("Style(%d).String() = %q, want %q",
+				int(style), got, want)
+		}
+	}
+}
+
+func TestFileLineFunc(t *testing.T) {
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	FileLineFunc.Log("message")
+	_, _, line, _ := runtime.Caller(0)
+	want := fmt.Sprint("github.com/platinasystems/dbg.TestFileLineFunc() ",
+		"style_test.go:", line-1, ": message\n")
+	if got := buf.String(); got != want {
+		t.Fatalf("\ngot:  %q\nwant: %q", got, want)
+	}
+	if s := FileLineFunc.String(); s != "FileLineFunc" {
+		t.Fatal("String:", s)
+	}
+}
+
+func TestStyleSet(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	var style Style
+	fs.Var(&style, "dbg", "debug style")
+	for _, name := range []string{"FileLine", "func", "PLAIN", "noop"} {
+		if err := fs.Parse([]string{"-dbg", name}); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.EqualFold(style.String(), name) {
+			t.Errorf("-dbg %s: got %v", name, style)
+		}
+	}
+	style = Func
+	err := style.Set("bogus")
+	if err == nil {
+		t.Fatal("no error for unknown style")
+	}
+	if style != Func {
+		t.Error("unknown style changed value to", style)
+	}
+	if !strings.Contains(err.Error(), "NoOp, Plain, FileLine") {
+		t.Error("error doesn't list valid styles:", err)
+	}
+}
+
+func TestParseStyle(t *testing.T) {
+	for name, want := range map[string]Style{
+		"FileLine": FileLine,
+		"fileline": FileLine,
+		"Func":     Func,
+		"json":     JSON,
+	} {
+		got, err := ParseStyle(name)
+		if err != nil {
+			t.Error(name, err)
+		} else if got != want {
+			t.Errorf("ParseStyle(%q) = %v, want %v", name, got, want)
+		}
+	}
+	for _, name := range []string{"", "bogus", "File Line"} {
+		if _, err := ParseStyle(name); err == nil {
+			t.Errorf("ParseStyle(%q): no error", name)
+		}
+	}
+}
+
+type shortFuncT struct{}
+
+func (*shortFuncT) log() string { return ShortFunc.Sprint("method") }
+
+func TestShortFunc(t *testing.T) {
+	closure := func() string { return ShortFunc.Sprint("closure") }
+	for _, x := range []struct{ got, want string }{
+		{ShortFunc.Sprint("top"), "dbg.TestShortFunc() top\n"},
+		{new(shortFuncT).log(), "dbg.(*shortFuncT).log() method\n"},
+		{closure(), "dbg.TestShortFunc.func1() closure\n"},
+		{shortfunc("main.main"), "main.main"},
+		{shortfunc("example.com/a/b.F[...]"), "b.F[...]"},
+		{shortfunc("gopkg.in/yaml%2ev3.(*T).M"), "yaml.v3.(*T).M"},
+		{shortfunc("example.com/a.F[go.shape.*example.com/b.T]"),
+			"a.F[go.shape.*example.com/b.T]"},
+	} {
+		if x.got != x.want {
+			t.Errorf("got %q, want %q", x.got, x.want)
+		}
+	}
+}
+
+func TestAbsFile(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	_, file, line, _ := runtime.Caller(0)
+	AbsFile.Log("absolute")
+	want := fmt.Sprint(file, ":", line+1, ": absolute\n")
+	if got := buf.String(); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if !filepath.IsAbs(file) {
+		t.Fatal("not absolute:", file)
+	}
+	if got := absfile("x.go"); got != filepath.Join(wd(), "x.go") {
+		t.Error("absfile(x.go) =", got)
+	}
+}
+
+func (*shortFuncT) pkg() string { return Pkg.Sprint("method") }
+
+func TestPkg(t *testing.T) {
+	for _, x := range []struct{ got, want string }{
+		{Pkg.Sprint("top"), "github.com/platinasystems/dbg: top\n"},
+		{new(shortFuncT).pkg(), "github.com/platinasystems/dbg: method\n"},
+		{pkgname("main.main"), "main"},
+		{pkgname("main.(*T).M.func1"), "main"},
+		{pkgname("example.com/a.b/c.F"), "example.com/a.b/c"},
+		{pkgname("example.com/a.F[go.shape.*example.com/b.T]"),
+			"example.com/a"},
+		{pkgname("example.com/m/lib%2ev2.F"), "example.com/m/lib.v2"},
+		{pkgname("gopkg.in/yaml%2ev3.(*T).M"), "gopkg.in/yaml.v3"},
+		{pkgname("example.com/m/lib%2ev2"), "example.com/m/lib.v2"},
+		{pkgname("example.com/m/100%zz.F"), "example.com/m/100%zz"},
+	} {
+		if x.got != x.want {
+			t.Errorf("got %q, want %q", x.got, x.want)
+		}
+	}
+}
+
+func TestShortFile(t *testing.T) {
+	_, _, line, _ := runtime.Caller(0)
+	got := ShortFile.Sprint("here")
+	if want := fmt.Sprint("style_test.go:", line+1, ": here\n"); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	fmt.Fprint(ShortFile.AsWriter(), "std")
+	if !strings.HasPrefix(buf.String(), "print.go:") {
+		t.Errorf("got %q", buf)
+	}
+}
+
+func TestLoglnLogfnn(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	_, _, line, _ := runtime.Caller(0)
+	FileLine.Logln("ln", 1)
+	FileLine.Logfnn("%s", "partial")
+	Plain.Logfnn(" %s;", "more")
+	Plain.Logln()
+	if err := Plain.Logfnn("%v", os.ErrInvalid); err != os.ErrInvalid {
+		t.Error("Logfnn didn't return error")
+	}
+	want := fmt.Sprint("style_test.go:", line+1, ": ln 1\n",
+		"style_test.go:", line+2, ": partial more;invalid argument")
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
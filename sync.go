@@ -0,0 +1,58 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"io"
+	"os"
+	"reflect"
+)
+
+// Flush each distinct writer, i.e. the Writer default, those of
+// WriterForStyle, and the ErrorWriter, that has a Flush() error or, lacking
+// that, a Sync() error method; e.g. before exit,
+//
+//	defer dbg.Sync()
+//
+// This skips os.Stdout and os.Stderr, which are unbuffered, and returns
+// the first error.
+func Sync() error {
+	ws := []io.Writer{CurrentWriter(), CurrentErrorWriter()}
+	for i := range styleWriters {
+		if w := unbox(&styleWriters[i]); w != nil {
+			ws = append(ws, w)
+		}
+	}
+	var err error
+	for i, w := range ws {
+		if w == os.Stdout || w == os.Stderr || containsWriter(ws[:i], w) {
+			continue
+		}
+		var werr error
+		switch v := w.(type) {
+		case interface{ Flush() error }:
+			werr = v.Flush()
+		case interface{ Sync() error }:
+			werr = v.Sync()
+		}
+		if err == nil {
+			err = werr
+		}
+	}
+	return err
+}
+
+// Return whether w is among ws; a writer of an incomparable type never is.
+func containsWriter(ws []io.Writer, w io.Writer) bool {
+	if !reflect.TypeOf(w).Comparable() {
+		return false
+	}
+	for _, x := range ws {
+		if x == w {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+type flushWriter struct {
+	bytes.Buffer
+	flushes int
+	err     error
+}
+
+func (w *flushWriter) Flush() error {
+	w.flushes++
+	return w.err
+}
+
+func TestSync(t *testing.T) {
+	defer Writer(CurrentWriter())
+	defer ErrorWriter(CurrentErrorWriter())
+	defer WriterForStyle(Plain, nil)
+	if err := Sync(); err != nil {
+		t.Errorf("stdout got %v", err)
+	}
+	w, plain := new(flushWriter), &flushWriter{err: os.ErrInvalid}
+	Writer(w)
+	ErrorWriter(w)
+	if err := Sync(); err != nil || w.flushes != 1 {
+		t.Errorf("got %v, %d flushes", err, w.flushes)
+	}
+	WriterForStyle(Plain, plain)
+	if err := Sync(); err != os.ErrInvalid {
+		t.Errorf("got %v, want %v", err, os.ErrInvalid)
+	}
+	if w.flushes != 2 || plain.flushes != 1 {
+		t.Errorf("got %d and %d flushes", w.flushes, plain.flushes)
+	}
+	Writer(new(bytes.Buffer))
+	ErrorWriter(nil)
+	WriterForStyle(Plain, nil)
+	if err := Sync(); err != nil {
+		t.Errorf("buffer got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows && !plan9
+
+package dbg
+
+import (
+	"fmt"
+	"io"
+	"log/syslog"
+	"os"
+	"strings"
+)
+
+// Tests may replace these to dial a fake syslog; empty is the local daemon.
+var syslogNetwork, syslogAddr string
+
+var syslogPriorities = map[string]syslog.Priority{
+	"emerg":   syslog.LOG_EMERG,
+	"alert":   syslog.LOG_ALERT,
+	"crit":    syslog.LOG_CRIT,
+	"err":     syslog.LOG_ERR,
+	"warning": syslog.LOG_WARNING,
+	"notice":  syslog.LOG_NOTICE,
+	"info":    syslog.LOG_INFO,
+	"debug":   syslog.LOG_DEBUG,
+}
+
+type syslogWriter struct {
+	w *syslog.Writer
+}
+
+// Return a writer to the local syslog daemon for use with dbg.Writer. The
+// priority is the case-insensitive severity name, one of: emerg, alert,
+// crit, err, warning, notice, info, or debug; the facility is LOG_USER. If
+// a syslog write fails, the writer copies the line to os.Stderr instead.
+func NewSyslogWriter(priority, tag string) (io.WriteCloser, error) {
+	prio, found := syslogPriorities[strings.ToLower(priority)]
+	if !found {
+		return nil, fmt.Errorf("dbg: unknown syslog priority %q",
+			priority)
+	}
+	w, err := syslog.Dial(syslogNetwork, syslogAddr, prio|syslog.LOG_USER,
+		tag)
+	if err != nil {
+		return nil, err
+	}
+	return &syslogWriter{w}, nil
+}
+
+func (sw *syslogWriter) Write(b []byte) (int, error) {
+	if _, err := sw.w.Write(b); err != nil {
+		return os.Stderr.Write(b)
+	}
+	return len(b), nil
+}
+
+func (sw *syslogWriter) Close() error {
+	return sw.w.Close()
+}
@@ -0,0 +1,17 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build windows || plan9
+
+package dbg
+
+import (
+	"errors"
+	"io"
+)
+
+// Syslog isn't available on this platform.
+func NewSyslogWriter(priority, tag string) (io.WriteCloser, error) {
+	return nil, errors.New("dbg: syslog not implemented")
+}
@@ -0,0 +1,51 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !windows && !plan9
+
+package dbg
+
+import (
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSyslogWriter(t *testing.T) {
+	addr := filepath.Join(t.TempDir(), "syslog")
+	conn, err := net.ListenPacket("unixgram", addr)
+	if err != nil {
+		t.Skip(err)
+	}
+	defer conn.Close()
+	defer func(network, addr string) {
+		syslogNetwork, syslogAddr = network, addr
+	}(syslogNetwork, syslogAddr)
+	syslogNetwork, syslogAddr = "unixgram", addr
+
+	if _, err = NewSyslogWriter("bogus", "dbgtest"); err == nil {
+		t.Fatal("no error for bogus priority")
+	}
+	w, err := NewSyslogWriter("Info", "dbgtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	NewLogger(Plain, w).Log("hello syslog")
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	b := make([]byte, 1024)
+	n, _, err := conn.ReadFrom(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := string(b[:n])
+	if !strings.HasPrefix(msg, "<14>") ||
+		!strings.Contains(msg, " dbgtest[") ||
+		!strings.HasSuffix(msg, ": hello syslog\n") {
+		t.Fatalf("%q", msg)
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"io"
+	"strings"
+)
+
+// The subset of testing.TB used by ForTB.
+type TB interface {
+	Helper()
+	Log(args ...interface{})
+}
+
+type tbWriter struct{ t TB }
+
+// Return a writer that passes each line, less trailing newline, to t.Log so
+// that it's shown with the test's output, e.g.
+//
+//	defer dbg.Writer(dbg.CurrentWriter())
+//	dbg.Writer(dbg.ForTB(t))
+//
+// The file and line that t.Log adds name dbg rather than the caller, so use
+// a FileLine style to show the latter.
+func ForTB(t TB) io.Writer {
+	return tbWriter{t}
+}
+
+// Return a Logger of the style that writes to ForTB(t).
+func (style Style) ForTB(t TB) *Logger {
+	return NewLogger(style, ForTB(t))
+}
+
+func (w tbWriter) Write(b []byte) (int, error) {
+	w.t.Helper()
+	w.t.Log(strings.TrimRight(string(b), "\r\n"))
+	return len(b), nil
+}
@@ -0,0 +1,37 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+type fakeTB struct{ logs []string }
+
+func (*fakeTB) Helper() {}
+
+func (tb *fakeTB) Log(args ...interface{}) {
+	tb.logs = append(tb.logs, fmt.Sprint(args...))
+}
+
+func TestForTB(t *testing.T) {
+	defer Writer(CurrentWriter())
+	tb := new(fakeTB)
+	Writer(ForTB(tb))
+	Plain.Log("default")
+	ShortFile.ForTB(tb).Log("logger")
+	_, _, line, _ := runtime.Caller(0)
+	want := []string{
+		"default",
+		fmt.Sprint("tb_test.go:", line-1, ": logger"),
+	}
+	if !reflect.DeepEqual(tb.logs, want) {
+		t.Errorf("got %q, want %q", tb.logs, want)
+	}
+	var _ TB = t
+}
@@ -0,0 +1,66 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// RFC3339 with microseconds.
+const DefaultTimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+var (
+	timeFormat  atomic.Value
+	start       atomic.Value
+	showElapsed int32
+	// Tests may replace this for deterministic timestamps.
+	nowFunc = time.Now
+)
+
+func init() {
+	ResetElapsed()
+}
+
+// Atomic change of the Time and TimeFileLine layout, see time.Format.
+func SetTimeFormat(layout string) {
+	timeFormat.Store(layout)
+}
+
+func timestamp() string {
+	layout, ok := timeFormat.Load().(string)
+	if !ok {
+		layout = DefaultTimeFormat
+	}
+	return nowFunc().Format(layout)
+}
+
+// Atomic change of whether each line begins with the time elapsed since
+// program start or the last ResetElapsed, e.g. "+1.234s ".
+func SetShowElapsed(enable bool) {
+	var v int32
+	if enable {
+		v = 1
+	}
+	atomic.StoreInt32(&showElapsed, v)
+}
+
+// Restart the elapsed time.
+func ResetElapsed() {
+	start.Store(nowFunc())
+}
+
+func elapsed() string {
+	d := nowFunc().Sub(start.Load().(time.Time))
+	return fmt.Sprintf("+%.3fs", d.Seconds())
+}
+
+func elapsedPrefix() string {
+	if atomic.LoadInt32(&showElapsed) == 0 {
+		return ""
+	}
+	return elapsed() + " "
+}
@@ -0,0 +1,62 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func fakeNow(t time.Time) func() {
+	now := nowFunc
+	nowFunc = func() time.Time { return t }
+	return func() { nowFunc = now }
+}
+
+func TestTime(t *testing.T) {
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	defer fakeNow(time.Date(2018, 10, 23, 10, 4, 5, 6000, time.UTC))()
+	Time.Log("plain")
+	TimeFileLine.Log("file")
+	_, _, line, _ := runtime.Caller(0)
+	SetTimeFormat(time.Kitchen)
+	defer SetTimeFormat(DefaultTimeFormat)
+	Time.Logf("%s", "kitchen")
+	want := fmt.Sprint("2018-10-23T10:04:05.000006Z plain\n",
+		"2018-10-23T10:04:05.000006Z time_test.go:", line-1, ": file\n",
+		"10:04AM kitchen\n")
+	if got := buf.String(); got != want {
+		t.Fatalf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestShowElapsed(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	now := time.Date(2018, 10, 23, 0, 0, 0, 0, time.UTC)
+	defer func(f func() time.Time) { nowFunc = f }(nowFunc)
+	nowFunc = func() time.Time { return now }
+	ResetElapsed()
+	defer ResetElapsed()
+	SetShowElapsed(true)
+	defer SetShowElapsed(false)
+	Plain.Log("start")
+	now = now.Add(1234 * time.Millisecond)
+	Func.Log("later")
+	ResetElapsed()
+	now = now.Add(time.Millisecond)
+	Plain.Log("reset")
+	want := "+0.000s start\n" +
+		"+1.234s github.com/platinasystems/dbg.TestShowElapsed() later\n" +
+		"+0.001s reset\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
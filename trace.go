@@ -0,0 +1,29 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import "runtime"
+
+// Log "enter NAME" and return a func that logs "exit NAME (DURATION)" with
+// the time elapsed since the Trace call. Both lines have the frame of the
+// Trace caller. Use this to time a function,
+//
+//	defer dbg.FileLine.Trace("myFunc")()
+func (style Style) Trace(name string) func() {
+	if !style.Enabled() {
+		return func() {}
+	}
+	l := &Logger{style: style}
+	var frame runtime.Frame
+	if l.framed() {
+		frame = caller(1)
+	}
+	t0 := nowFunc()
+	l.write(Info, frame, "", nil, []interface{}{"enter", name})
+	return func() {
+		l.write(Info, frame, "%s %s (%v)", nil, []interface{}{"exit",
+			name, nowFunc().Sub(t0)})
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestTrace(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	t0 := time.Date(2018, 10, 23, 10, 4, 5, 0, time.UTC)
+	defer fakeNow(t0)()
+	exit := ShortFile.Trace("myFunc")
+	_, _, line, _ := runtime.Caller(0)
+	nowFunc = func() time.Time { return t0.Add(1230 * time.Microsecond) }
+	exit()
+	want := fmt.Sprint("trace_test.go:", line-1, ": enter myFunc\n",
+		"trace_test.go:", line-1, ": exit myFunc (1.23ms)\n")
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestTraceNoOp(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	NoOp.Trace("myFunc")()
+	if buf.Len() != 0 {
+		t.Errorf("got %q", buf)
+	}
+}
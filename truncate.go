@@ -0,0 +1,42 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"fmt"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+var maxLen int32
+
+// Atomic change of the maximum runes of a formatted message; longer
+// messages are cut with a suffix of the dropped length, e.g.
+//
+//	0123456789 …(truncated, 90 bytes)
+//
+// The style prefix is never cut. The default, 0, is unlimited.
+func SetMaxLen(n int) {
+	if n < 0 {
+		n = 0
+	}
+	atomic.StoreInt32(&maxLen, int32(n))
+}
+
+func truncate(s string) string {
+	n := int(atomic.LoadInt32(&maxLen))
+	if n == 0 || len(s) <= n {
+		return s
+	}
+	i := 0
+	for runes := 0; i < len(s) && runes < n; runes++ {
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+	}
+	if i == len(s) {
+		return s
+	}
+	return fmt.Sprintf("%s …(truncated, %d bytes)", s[:i], len(s)-i)
+}
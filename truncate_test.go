@@ -0,0 +1,33 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMaxLen(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	SetMaxLen(5)
+	defer SetMaxLen(0)
+	Pkg.Log("abc")
+	Pkg.Log("abcde")
+	Pkg.Log("abcdefgh")
+	Pkg.Log("héllo wörld")
+	SetMaxLen(0)
+	Pkg.Log("abcdefgh")
+	const p = "github.com/platinasystems/dbg: "
+	want := p + "abc\n" +
+		p + "abcde\n" +
+		p + "abcde …(truncated, 3 bytes)\n" +
+		p + "héllo …(truncated, 7 bytes)\n" +
+		p + "abcdefgh\n"
+	if got := buf.String(); got != want {
+		t.Errorf("\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import "sync/atomic"
+
+var verbosity int32
+
+// Atomic change of the verbosity threshold; the default, 0, suppresses all
+// V(n) messages with n > 0.
+func SetVerbosity(n int) {
+	atomic.StoreInt32(&verbosity, int32(n))
+}
+
+// Return the verbosity threshold.
+func Verbosity() int {
+	return int(atomic.LoadInt32(&verbosity))
+}
+
+// Return the style if the verbosity threshold is at least level; otherwise,
+// NoOp. Use this for leveled tracing,
+//
+//	dbg.FileLine.V(2).Logf("%d entries", n)
+func (style Style) V(level int) Style {
+	if level > Verbosity() {
+		return NoOp
+	}
+	return style
+}
+
+// Like Style.V but of the default logger's style, that of DBG_STYLE or
+// FileLine unless changed by SetDefaultStyle, e.g.
+//
+//	dbg.V(2).Logf("%d entries", n)
+//
+// The returned style prints to its own writer without the default logger's
+// writer or prefix.
+func V(level int) Style {
+	return DefaultLogger().style.V(level)
+}
@@ -0,0 +1,58 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestVerbosity(t *testing.T) {
+	defer Writer(CurrentWriter())
+	defer SetVerbosity(Verbosity())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	SetVerbosity(0)
+	Plain.V(0).Log("zero")
+	Plain.V(1).Log("one")
+	if err := Plain.V(1).Log(os.ErrInvalid); err != os.ErrInvalid {
+		t.Error("suppressed didn't return error")
+	}
+	SetVerbosity(2)
+	Plain.V(1).Log("one")
+	Plain.V(2).Logf("%s", "two")
+	Plain.V(3).Log("three")
+	if got, want := buf.String(), "zero\none\ntwo\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestV(t *testing.T) {
+	defer Writer(CurrentWriter())
+	defer SetVerbosity(Verbosity())
+	defer func(l *Logger) { defaultLogger.l.Store(l) }(DefaultLogger())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	SetDefaultStyle(FileLine)
+	SetVerbosity(5)
+	_, _, line, _ := runtime.Caller(0)
+	V(2).Logf("%d entries", 3)
+	V(6).Log("suppressed")
+	SetDefaultStyle(Plain)
+	SetVerbosity(1)
+	if style := V(1); style != Plain {
+		t.Errorf("V(1) got %v, want Plain", style)
+	}
+	if style := V(2); style != NoOp {
+		t.Errorf("V(2) got %v, want NoOp", style)
+	}
+	if got, want := buf.String(), fmt.Sprint("verbose_test.go:", line+1,
+		": 3 entries\n"); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import "fmt"
+
+// Return err wrapped with the caller's file, line, and msg, e.g.
+//
+//	return dbg.FileLine.WrapErr(err, "open config")
+//	// dbg/config.go:18: open config: file does not exist
+//
+// The new error wraps err for errors.Is and errors.As. Unless NoOp, the
+// style also logs "msg: err" with the caller's frame. If err is nil, WrapErr
+// returns nil without logging.
+func (style Style) WrapErr(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	frame := caller(1)
+	werr := fmt.Errorf("%s:%d: %s: %w", relfile(frame.File), frame.Line,
+		msg, err)
+	if style.Enabled() {
+		l := &Logger{style: style}
+		l.write(Info, frame, "%s: %v", werr, []interface{}{msg, err})
+	}
+	return werr
+}
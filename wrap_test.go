@@ -0,0 +1,48 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestWrapErr(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	err := FileLine.WrapErr(os.ErrNotExist, "open config")
+	_, _, line, _ := runtime.Caller(0)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Error("doesn't wrap os.ErrNotExist")
+	}
+	want := fmt.Sprint("wrap_test.go:", line-1,
+		": open config: file does not exist")
+	if got := err.Error(); got != want {
+		t.Errorf("error got %q, want %q", got, want)
+	}
+	if got := buf.String(); got != want+"\n" {
+		t.Errorf("logged %q, want %q", got, want+"\n")
+	}
+}
+
+func TestWrapErrNil(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	if err := FileLine.WrapErr(nil, "nothing"); err != nil {
+		t.Errorf("got %v, want nil", err)
+	}
+	if err := NoOp.WrapErr(os.ErrNotExist, "quiet"); err == nil {
+		t.Error("NoOp didn't wrap")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("logged %q", buf)
+	}
+}
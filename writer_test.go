@@ -0,0 +1,166 @@
+// Copyright 2018 Platina Systems, Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbg
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sync"
+	"testing"
+)
+
+func TestWriterForStyle(t *testing.T) {
+	def, plain, fileLine := new(bytes.Buffer), new(bytes.Buffer),
+		new(bytes.Buffer)
+	Writer(def)
+	WriterForStyle(Plain, plain)
+	WriterForStyle(FileLine, fileLine)
+	defer WriterForStyle(Plain, nil)
+	defer WriterForStyle(FileLine, nil)
+	Plain.Log("plain")
+	FileLine.Log("fileline")
+	Func.Log("func")
+	if got, want := plain.String(), "plain\n"; got != want {
+		t.Errorf("Plain got %q, want %q", got, want)
+	}
+	if got := fileLine.String(); !bytes.HasSuffix(fileLine.Bytes(),
+		[]byte(": fileline\n")) {
+		t.Errorf("FileLine got %q", got)
+	}
+	if got, want := def.String(),
+		"github.com/platinasystems/dbg.TestWriterForStyle() func\n"; got != want {
+		t.Errorf("default got %q, want %q", got, want)
+	}
+	WriterForStyle(Plain, nil)
+	Plain.Log("reverted")
+	if !bytes.HasSuffix(def.Bytes(), []byte("\nreverted\n")) {
+		t.Errorf("reverted got %q", def)
+	}
+}
+
+func TestCurrentWriter(t *testing.T) {
+	defer Writer(CurrentWriter())
+	buf := new(bytes.Buffer)
+	Writer(buf)
+	if w := CurrentWriter(); w != buf {
+		t.Fatalf("got %T, want buf", w)
+	}
+	Writer(nil)
+	if w := CurrentWriter(); w != os.Stdout {
+		t.Fatalf("got %T, want os.Stdout", w)
+	}
+}
+
+func TestErrorsToStderr(t *testing.T) {
+	defer Writer(CurrentWriter())
+	defer ErrorWriter(CurrentErrorWriter())
+	out, errs := new(bytes.Buffer), new(bytes.Buffer)
+	Writer(out)
+	ErrorWriter(errs)
+	SetErrorsToStderr(true)
+	defer SetErrorsToStderr(false)
+	Plain.Log("info")
+	if err := Plain.Log(os.ErrInvalid, "error"); err != os.ErrInvalid {
+		t.Error("didn't return error")
+	}
+	Plain.Log("error", os.ErrInvalid)
+	if got, want := out.String(), "info\nerror invalid argument\n"; got != want {
+		t.Errorf("out got %q, want %q", got, want)
+	}
+	if got, want := errs.String(), "invalid argument error\n"; got != want {
+		t.Errorf("errs got %q, want %q", got, want)
+	}
+	SetErrorsToStderr(false)
+	Plain.Log(os.ErrInvalid)
+	if got, want := out.String(), "info\nerror invalid argument\n"+
+		"invalid argument\n"; got != want {
+		t.Errorf("disabled got %q, want %q", got, want)
+	}
+	ErrorWriter(nil)
+	if w := CurrentErrorWriter(); w != os.Stderr {
+		t.Errorf("got %T, want os.Stderr", w)
+	}
+}
+
+func TestStderrStdout(t *testing.T) {
+	defer Writer(CurrentWriter())
+	Stderr()
+	if w := CurrentWriter(); w != os.Stderr {
+		t.Fatalf("got %T, want os.Stderr", w)
+	}
+	Writer(new(bytes.Buffer))
+	Stdout()
+	if w := CurrentWriter(); w != os.Stdout {
+		t.Fatalf("got %T, want os.Stdout", w)
+	}
+	Stderr()
+	ResetWriter()
+	if w := CurrentWriter(); w != os.Stdout {
+		t.Fatalf("got %T, want os.Stdout", w)
+	}
+}
+
+func TestWriterNil(t *testing.T) {
+	defer Writer(CurrentWriter())
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer func(f *os.File) { os.Stdout = f }(os.Stdout)
+	os.Stdout = w
+	Writer(nil)
+	Plain.Log("nil")
+	var typed *bytes.Buffer
+	Writer(typed)
+	Plain.Log("typed nil")
+	w.Close()
+	b, _ := io.ReadAll(r)
+	if got, want := string(b), "nil\ntyped nil\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func TestWriterRace(t *testing.T) {
+	defer Writer(CurrentWriter())
+	bufs := []*lockedBuffer{new(lockedBuffer), new(lockedBuffer)}
+	Writer(bufs[0])
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				Writer(bufs[(i+j)%len(bufs)])
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				Plain.Log("race")
+			}
+		}()
+	}
+	wg.Wait()
+	n := 0
+	for _, b := range bufs {
+		n += bytes.Count(b.buf.Bytes(), []byte("race\n"))
+	}
+	if want := 16 * 100; n != want {
+		t.Errorf("got %d lines, want %d", n, want)
+	}
+}